@@ -0,0 +1,164 @@
+package tries
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Bits is a key made up of any number of bits, rather than whole bytes, for
+// a [Trie] created with [BitsCodec]. It suits keys whose meaningful prefixes
+// do not fall on byte boundaries, such as geohashes (5 bits per character)
+// or bit-packed feature vectors. Bits are ordered one at a time, most
+// significant first, with a prefix sorting before its extensions.
+type Bits struct {
+	data   []uint8 // data holds the bits, most significant first, with any bits beyond length cleared
+	length int
+}
+
+// BitsFrom creates [Bits] from the first length bits of data, most
+// significant bit of each byte first. A length beyond the bits available in
+// data is shortened to fit.
+func BitsFrom(data []uint8, length int) Bits {
+	length = max(0, min(length, len(data)*8))
+	bits := Bits{data: make([]uint8, (length+7)/8), length: length}
+	copy(bits.data, data)
+	if remainder := length % 8; remainder > 0 {
+		bits.data[len(bits.data)-1] &= 0xFF << (8 - remainder)
+	}
+
+	return bits
+}
+
+// Append returns a copy of these [Bits] followed by the lowest width bits of
+// value, most significant first.
+func (this Bits) Append(value uint64, width int) Bits {
+	width = max(0, min(width, 64))
+	appended := BitsFrom(this.data, this.length)
+	for i := width - 1; i >= 0; i-- {
+		appended = appended.appendBit(uint8(value>>i) & 1) //nolint:gosec // this casting is fine
+	}
+
+	return appended
+}
+
+func (this Bits) appendBit(bit uint8) Bits {
+	if this.length%8 == 0 {
+		this.data = append(this.data, 0)
+	}
+
+	this.data[this.length/8] |= bit << (7 - this.length%8)
+	this.length++
+	return this
+}
+
+// Len returns the number of bits.
+func (this Bits) Len() int {
+	return this.length
+}
+
+// At returns the bit at the provided index (0 or 1), or 0 if the index is out
+// of range.
+func (this Bits) At(index int) uint8 {
+	if index < 0 || index >= this.length {
+		return 0
+	}
+
+	return this.data[index/8] >> (7 - index%8) & 1
+}
+
+// Bytes returns a copy of the bits packed into bytes, most significant bit
+// first, with the last byte padded with zeros.
+func (this Bits) Bytes() []uint8 {
+	return append([]uint8(nil), this.data...)
+}
+
+// String renders the bits as a string of 0s and 1s.
+func (this Bits) String() string {
+	var builder strings.Builder
+	for index := range this.length {
+		builder.WriteByte('0' + this.At(index))
+	}
+
+	return builder.String()
+}
+
+// BitsCodec is a [KeyCodec] for [Bits] keys, which stores each bit as its own
+// path unit. It needs no [WithUnitBits], which would split those units again.
+type BitsCodec struct{}
+
+func (BitsCodec) Encode(key Bits, path []uint8) (encoded []uint8, err error) {
+	for index := range key.length {
+		path = append(path, key.At(index))
+	}
+
+	return path, nil
+}
+
+func (BitsCodec) Decode(path []uint8) (key Bits, err error) {
+	key.data = make([]uint8, 0, (len(path)+7)/8)
+	for _, unit := range path {
+		if unit > 1 {
+			return Bits{}, fmt.Errorf("%w: expected a bit but found %d", ErrorBadTrieKey, unit)
+		}
+
+		key = key.appendBit(unit)
+	}
+
+	return key, nil
+}
+
+// unitStage splits each byte into units of bits, most significant first.
+type unitStage struct {
+	bits int
+}
+
+func newUnitStage(bits int) func() transformStage {
+	return func() transformStage {
+		return &unitStage{bits: bits}
+	}
+}
+
+func (this *unitStage) reset() {}
+
+func (this *unitStage) push(unit uint8, out []uint8) []uint8 {
+	mask := uint8(1)<<this.bits - 1
+	for shift := 8 - this.bits; shift >= 0; shift -= this.bits {
+		out = append(out, unit>>shift&mask)
+	}
+
+	return out
+}
+
+func (this *unitStage) flush(out []uint8) []uint8 {
+	return out
+}
+
+// aligned is `false` because the units of a split byte are not bytes, and a
+// key could match starting partway through one.
+func (this *unitStage) aligned() bool {
+	return false
+}
+
+func (this *unitStage) option() string {
+	return "WithUnitBits"
+}
+
+// packUnits reverses a unitStage, joining units of bits back into bytes.
+func packUnits(path []uint8, bits int) (packed []uint8, err error) {
+	perByte := 8 / bits
+	if len(path)%perByte != 0 {
+		return nil, fmt.Errorf("%w: expected a multiple of %d units but found %d", ErrorBadTrieKey, perByte, len(path))
+	}
+
+	packed = make([]uint8, 0, len(path)/perByte)
+	for ; len(path) > 0; path = path[perByte:] {
+		var unit uint8
+		for _, part := range path[:perByte] {
+			unit = unit<<bits | part
+		}
+
+		packed = append(packed, unit)
+	}
+
+	return packed, nil
+}
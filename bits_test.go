@@ -0,0 +1,115 @@
+package tries
+
+import (
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func Test_Bits(t *testing.T) {
+	bits := BitsFrom([]uint8{0b1011_0111, 0xFF}, 10)
+	assert := assertions.New(t)
+	assert.So(bits.Len(), should.Equal, 10)
+	assert.So(bits.String(), should.Equal, "1011011111")
+	assert.So(bits.Bytes(), should.Equal, []uint8{0b1011_0111, 0b1100_0000})
+	assert.So(bits.At(0), should.Equal, 1)
+	assert.So(bits.At(1), should.Equal, 0)
+	assert.So(bits.At(10), should.Equal, 0)
+
+	appended := bits.Append(0b10101, 5)
+	assert.So(appended.String(), should.Equal, "101101111110101")
+	assert.So(bits.String(), should.Equal, "1011011111")
+	assert.So(BitsFrom([]uint8{0xFF}, 20).Len(), should.Equal, 8)
+}
+
+func Test_BitsCodec(t *testing.T) {
+	geohash := func(codes ...uint64) (bits Bits) {
+		for _, code := range codes {
+			bits = bits.Append(code, 5)
+		}
+
+		return bits
+	}
+
+	trie, _ := NewTrieWithCodec[Bits, string](BitsCodec{}, WithSubtreeCounts())
+	trie.Add(geohash(9, 16, 11), "a")
+	trie.Add(geohash(9, 16, 12), "b")
+	trie.Add(geohash(9, 17, 0), "c")
+	trie.Add(geohash(10, 0, 0), "d")
+
+	testTable := map[string]struct {
+		Prefix   Bits
+		Expected []Entry[Bits, string]
+	}{
+		"one-character": {Prefix: geohash(9), Expected: []Entry[Bits, string]{
+			{geohash(9, 16, 11), "a"}, {geohash(9, 16, 12), "b"}, {geohash(9, 17, 0), "c"},
+		}},
+		"two-characters": {Prefix: geohash(9, 16), Expected: []Entry[Bits, string]{
+			{geohash(9, 16, 11), "a"}, {geohash(9, 16, 12), "b"},
+		}},
+		"sub-character": {Prefix: geohash(9).Append(0b1000, 4), Expected: []Entry[Bits, string]{
+			{geohash(9, 16, 11), "a"}, {geohash(9, 16, 12), "b"}, {geohash(9, 17, 0), "c"},
+		}},
+		"not-in-data": {Prefix: geohash(11), Expected: nil},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			assertions.New(t).So(collectEntries(trie.WithPrefix(testCase.Prefix)), should.Equal, testCase.Expected)
+			assertions.New(t).So(trie.CountPrefix(testCase.Prefix), should.Equal, len(testCase.Expected))
+		})
+	}
+
+	_, err := BitsCodec{}.Decode([]uint8{0, 2})
+	assertions.New(t).So(err, should.Wrap, ErrorBadTrieKey)
+}
+
+func Test_WithUnitBits(t *testing.T) {
+	for _, bits := range []int{1, 2, 4, 8} {
+		trie, err := NewTrieWithOptions[[]uint8, int](WithUnitBits(bits), WithSubtreeCounts())
+		assertions.New(t).So(err, should.BeNil)
+
+		trie.Add([]uint8{0x12, 0x34}, 1)
+		trie.Add([]uint8{0x12, 0x3F}, 2)
+		trie.Add([]uint8{0x12}, 3)
+		trie.Add([]uint8{0x80}, 4)
+
+		assert := assertions.New(t)
+		value, found := trie.Find([]uint8{0x12, 0x3F})
+		assert.So(value, should.Equal, 2)
+		assert.So(found, should.BeTrue)
+		assert.So(trie.Length(), should.Equal, 4)
+		assert.So(trie.CountPrefix([]uint8{0x12}), should.Equal, 3)
+		assert.So(collectEntries(trie.All()), should.Equal, []Entry[[]uint8, int]{
+			{[]uint8{0x12}, 3}, {[]uint8{0x12, 0x34}, 1}, {[]uint8{0x12, 0x3F}, 2}, {[]uint8{0x80}, 4},
+		})
+
+		walker := trie.Walker()
+		assert.So(walker.Step(0x12), should.BeTrue)
+		assert.So(walker.Value(), should.Equal, 3)
+		assert.So(walker.Step(0x34), should.BeTrue)
+		assert.So(walker.Value(), should.Equal, 1)
+	}
+}
+
+func Test_WithUnitBits_Nibbles(t *testing.T) {
+	trie, _ := NewTrieWithOptions[[]uint8, int](WithUnitBits(4))
+	trie.Add([]uint8{0x12, 0x34}, 1)
+
+	// Every byte takes two nodes, one per nibble.
+	node := &trie.head
+	var units []uint8
+	for len(node.next) > 0 {
+		node = &node.next[0]
+		units = append(units, node.key)
+	}
+
+	assertions.New(t).So(units, should.Equal, []uint8{0x1, 0x2, 0x3, 0x4})
+}
+
+func Test_WithUnitBits_Bad(t *testing.T) {
+	trie, err := NewTrieWithOptions[string, int](WithUnitBits(3))
+	assertions.New(t).So(trie, should.BeNil)
+	assertions.New(t).So(err, should.Wrap, ErrorBadUnitBits)
+}
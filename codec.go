@@ -0,0 +1,56 @@
+package tries
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// TimeCodec is a [KeyCodec] for [time.Time] keys. Each instant is encoded
+// as 12 bytes, its seconds since the Unix epoch followed by its nanoseconds,
+// so keys are ordered chronologically. Times are compared as instants: the
+// location and any monotonic clock reading are not stored, times that are
+// [time.Time.Equal] share a key, and decoded keys are in UTC.
+type TimeCodec struct{}
+
+func (TimeCodec) Encode(key time.Time, path []uint8) (encoded []uint8, err error) {
+	path = binary.BigEndian.AppendUint64(path, uint64(key.Unix())^signBit64) //nolint:gosec // this casting is fine
+	return binary.BigEndian.AppendUint32(path, uint32(key.Nanosecond())), nil
+}
+
+func (TimeCodec) Decode(path []uint8) (key time.Time, err error) {
+	const width = 12
+	if len(path) != width {
+		return key, fmt.Errorf("%w: expected %d bytes but found %d", ErrorBadTrieKey, width, len(path))
+	}
+
+	seconds := int64(binary.BigEndian.Uint64(path) ^ signBit64) //nolint:gosec // this casting is fine
+	nanoseconds := int64(binary.BigEndian.Uint32(path[8:]))
+	return time.Unix(seconds, nanoseconds).UTC(), nil
+}
+
+// ArrayCodec is a [KeyCodec] for fixed-size byte array keys, such as UUIDs,
+// hash digests or IP addresses, including named array types. Each key is
+// stored as its bytes in order. Unlike a slice, an array key is not copied to
+// the heap, so lookups by it do not allocate.
+type ArrayCodec[TKey TrieArray] struct{}
+
+func (ArrayCodec[TKey]) Encode(key TKey, path []uint8) (encoded []uint8, err error) {
+	for index := range len(key) {
+		path = append(path, key[index])
+	}
+
+	return path, nil
+}
+
+func (ArrayCodec[TKey]) Decode(path []uint8) (key TKey, err error) {
+	if len(path) != len(key) {
+		return key, fmt.Errorf("%w: expected %d bytes but found %d", ErrorBadTrieKey, len(key), len(path))
+	}
+
+	for index := range len(key) {
+		key[index] = path[index]
+	}
+
+	return key, nil
+}
@@ -0,0 +1,135 @@
+package tries
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+type testPoint struct {
+	X, Y uint8
+}
+
+type testPointCodec struct{}
+
+func (testPointCodec) Encode(key testPoint, path []uint8) (encoded []uint8, err error) {
+	return append(path, key.X, key.Y), nil
+}
+
+func (testPointCodec) Decode(path []uint8) (key testPoint, err error) {
+	if len(path) != 2 {
+		return key, ErrorBadTrieKey
+	}
+
+	return testPoint{X: path[0], Y: path[1]}, nil
+}
+
+func Test_NewTrieWithCodec(t *testing.T) {
+	trie, err := NewTrieWithCodec[testPoint, string](testPointCodec{}, WithSubtreeCounts())
+	assertions.New(t).So(err, should.BeNil)
+
+	trie.Add(testPoint{2, 1}, "c")
+	trie.Add(testPoint{1, 2}, "b")
+	trie.Add(testPoint{1, 1}, "a")
+	trie.Add(testPoint{1, 1}, "A")
+
+	testTable := map[string]struct {
+		Key           testPoint
+		ExpectedValue string
+		ExpectedFound bool
+	}{
+		"first":       {Key: testPoint{1, 1}, ExpectedValue: "A", ExpectedFound: true},
+		"second":      {Key: testPoint{1, 2}, ExpectedValue: "b", ExpectedFound: true},
+		"third":       {Key: testPoint{2, 1}, ExpectedValue: "c", ExpectedFound: true},
+		"not-in-data": {Key: testPoint{2, 2}, ExpectedValue: "", ExpectedFound: false},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			value, found := trie.Find(testCase.Key)
+			assertions.New(t).So(value, should.Equal, testCase.ExpectedValue)
+			assertions.New(t).So(found, should.Equal, testCase.ExpectedFound)
+		})
+	}
+
+	assert := assertions.New(t)
+	assert.So(trie.Length(), should.Equal, 3)
+	assert.So(trie.Rank(testPoint{2, 1}), should.Equal, 2)
+	assert.So(collectEntries(trie.All()), should.Equal, []Entry[testPoint, string]{
+		{testPoint{1, 1}, "A"}, {testPoint{1, 2}, "b"}, {testPoint{2, 1}, "c"},
+	})
+}
+
+func Test_NewTrieWithCodec_NilCodec(t *testing.T) {
+	trie, err := NewTrieWithCodec[testPoint, string](nil)
+	assertions.New(t).So(trie, should.BeNil)
+	assertions.New(t).So(err, should.Wrap, ErrorBadTrieKey)
+}
+
+func Test_TimeCodec(t *testing.T) {
+	trie, _ := NewTrieWithCodec[time.Time, string](TimeCodec{})
+
+	eastern := time.FixedZone("EST", -5*60*60)
+	before := time.Date(1969, time.July, 20, 20, 17, 40, 0, time.UTC)
+	epoch := time.Unix(0, 0).UTC()
+	after := time.Date(2024, time.February, 29, 12, 0, 0, 500, time.UTC)
+
+	trie.Add(after, "after")
+	trie.Add(epoch, "epoch")
+	trie.Add(before, "before")
+
+	assert := assertions.New(t)
+	value, found := trie.Find(after.In(eastern))
+	assert.So(value, should.Equal, "after")
+	assert.So(found, should.BeTrue)
+	assert.So(collectEntries(trie.All()), should.Equal, []Entry[time.Time, string]{
+		{before, "before"}, {epoch, "epoch"}, {after, "after"},
+	})
+}
+
+type testUUID [16]uint8
+
+func Test_ArrayCodec(t *testing.T) {
+	trie, _ := NewTrieWithCodec[testUUID, int](ArrayCodec[testUUID]{})
+	trie.Add(testUUID{0xFF, 15: 0x01}, 1)
+	trie.Add(testUUID{0x01, 15: 0xFF}, 2)
+	trie.Add(testUUID{}, 3)
+
+	testTable := map[string]struct {
+		Input    testUUID
+		Expected int
+		OK       bool
+	}{
+		"high":        {Input: testUUID{0xFF, 15: 0x01}, Expected: 1, OK: true},
+		"low":         {Input: testUUID{0x01, 15: 0xFF}, Expected: 2, OK: true},
+		"zero":        {Input: testUUID{}, Expected: 3, OK: true},
+		"not-in-data": {Input: testUUID{0xFF}, Expected: 0, OK: false},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			and := assertions.New(t)
+			actual, ok := trie.Find(testCase.Input)
+			and.So(actual, should.Equal, testCase.Expected)
+			and.So(ok, should.Equal, testCase.OK)
+		})
+	}
+
+	assertions.New(t).So(collectEntries(trie.All()), should.Equal, []Entry[testUUID, int]{
+		{testUUID{}, 3}, {testUUID{0x01, 15: 0xFF}, 2}, {testUUID{0xFF, 15: 0x01}, 1},
+	})
+
+	_, err := ArrayCodec[testUUID]{}.Decode(make([]uint8, 15))
+	assertions.New(t).So(err, should.Wrap, ErrorBadTrieKey)
+}
+
+func Test_ArrayCodec_FindDoesNotAllocate(t *testing.T) {
+	trie, _ := NewTrieWithCodec[[32]uint8, int](ArrayCodec[[32]uint8]{})
+	key := [32]uint8{1, 2, 3}
+	trie.Add(key, 1)
+
+	allocations := testing.AllocsPerRun(100, func() { trie.Find(key) })
+	assertions.New(t).So(allocations, should.Equal, 0)
+}
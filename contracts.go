@@ -7,6 +7,16 @@ type (
 		~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uint | ~uintptr | ~int8 | ~int16 | ~int32 | ~int64 | ~int
 	}
 
+	// TrieFloat defines any floating-point types that can be used as a key
+	// type for a [Trie]. Floats are ordered numerically, with -0 sorting
+	// just before (and stored apart from) +0, and NaNs stored according to
+	// their bits: those with the sign bit set sort before -Inf, and all others
+	// after +Inf. NaNs with the same bits share a key, so a NaN can be found
+	// even though it does not equal itself.
+	TrieFloat interface {
+		~float32 | ~float64
+	}
+
 	// TrieString defines any string types that can be used as a key type for a
 	// [Trie].
 	TrieString interface {
@@ -16,7 +26,14 @@ type (
 	// TrieSlice defines any slice types that can be used as a key type for a
 	// [Trie].
 	TrieSlice interface {
-		~[]uint8 | ~[]uint16 | ~[]uint32 | ~[]uint64 | ~[]uint | ~[]uintptr | ~[]int8 | ~[]int16 | ~[]int32 | ~[]int64 | ~[]int
+		~[]uint8 | ~[]uint16 | ~[]uint32 | ~[]uint64 | ~[]uint | ~[]uintptr | ~[]int8 | ~[]int16 | ~[]int32 | ~[]int64 | ~[]int | ~[]float32 | ~[]float64
+	}
+
+	// TrieArray defines the fixed-size byte array types that [ArrayCodec] can
+	// encode, covering IPv4 and IPv6 addresses, MAC addresses, UUIDs and
+	// common hash digests.
+	TrieArray interface {
+		~[4]uint8 | ~[6]uint8 | ~[8]uint8 | ~[12]uint8 | ~[16]uint8 | ~[20]uint8 | ~[32]uint8 | ~[64]uint8
 	}
 
 	// TrieIntegerString marries the [TrieInteger] and [TrieString] together.
@@ -24,12 +41,17 @@ type (
 		TrieInteger | TrieString
 	}
 
-	// TrieKey defines all types that can be used as a key type for a [Trie].
+	// TrieKey defines all types with a built-in encoding, which can be used as
+	// a key type for a [Trie] created with [NewTrie]. Any other type can be
+	// used as a key by supplying a [KeyCodec] to [NewTrieWithCodec].
 	TrieKey interface {
-		TrieIntegerString | TrieSlice
+		TrieIntegerString | TrieFloat | TrieSlice
 	}
 
-	Trie[TKey TrieKey, TValue any] interface {
+	// Trie maps keys to values along paths of units (bytes) derived from each
+	// key, passing through any transforms. Queries beyond exact lookups, such
+	// as iteration and prefix searches, are provided by [SimpleTrie].
+	Trie[TKey any, TValue any] interface {
 		// Add inserts a new key-value pair, overwriting any extant value if the
 		// key is already present.
 		//
@@ -58,6 +80,165 @@ type (
 		Length() (length int)
 	}
 
+	// EditCosts prices the edits that turn a query into a stored key for
+	// [SimpleTrie.FuzzyFindCosts]. Costs must not be negative. Integer costs
+	// keep comparisons exact; scale them up to express fractional weights (e.g.
+	// 2 for a regular edit and 1 for a cheap one).
+	EditCosts interface {
+		// Insert returns the cost of a unit that is in the key but not the
+		// query.
+		Insert(unit uint8) (cost int)
+
+		// Delete returns the cost of a unit that is in the query but not the
+		// key.
+		Delete(unit uint8) (cost int)
+
+		// Substitute returns the cost of the query unit from appearing as the
+		// key unit to. It is never called with equal units.
+		Substitute(from, to uint8) (cost int)
+
+		// Transpose returns the cost of the adjacent query units first and
+		// second appearing in the opposite order in the key, and whether such
+		// a transposition is allowed at all.
+		Transpose(first, second uint8) (cost int, allowed bool)
+	}
+
+	// Entry is a single key-value pair stored in a [Trie].
+	Entry[TKey any, TValue any] struct {
+		Key   TKey
+		Value TValue
+	}
+
+	// Cursor is a stateful position within a [Trie] that can move forward and
+	// backward in trie order. A Cursor must not be used after the [Trie] it was
+	// created from has been modified; reposition it with one of the seeking
+	// methods first.
+	//
+	// Every positioning method returns `true` if the cursor now rests on an
+	// entry, or `false` if it has run off either end of the [Trie].
+	Cursor[TKey any, TValue any] interface {
+		// First moves to the first entry.
+		First() bool
+
+		// Last moves to the last entry.
+		Last() bool
+
+		// Seek moves to the first entry whose key is equal to or sorts after
+		// the provided key.
+		Seek(key TKey) bool
+
+		// SeekPrefix moves to the first entry whose key starts with the
+		// provided prefix. If no such entry exists, the cursor is invalidated.
+		SeekPrefix(prefix TKey) bool
+
+		// Next moves to the following entry.
+		Next() bool
+
+		// Prev moves to the preceding entry.
+		Prev() bool
+
+		// Valid reports whether the cursor currently rests on an entry.
+		Valid() bool
+
+		// Key returns the key of the current entry, or the zero value if the
+		// cursor is not valid.
+		Key() (key TKey)
+
+		// Value returns the value of the current entry, or the zero value if
+		// the cursor is not valid.
+		Value() (value TValue)
+	}
+
+	// Occurrence is a stored key found within a text by a [Scanner].
+	Occurrence[TKey any, TValue any] struct {
+		Key   TKey   // Key is the stored key, as reported by the [Trie] the [Scanner] was compiled from.
+		Value TValue // Value is the value stored alongside Key.
+		Start int    // Start is the offset in the text of the first byte of the occurrence.
+		End   int    // End is the offset in the text just after the last byte of the occurrence.
+	}
+
+	// Walker descends a [Trie] one key unit (byte) at a time, which allows a
+	// caller to match keys against a stream of input without re-walking from
+	// the root after each unit. Units are passed through the same transforms as
+	// [Trie.Find], so feeding a Walker the bytes of a key one at a time arrives
+	// at the same node that [Trie.Find] would. Transforms that hold units
+	// back until they have seen more of the key, such as those created
+	// [WithRuneTransforms] while a UTF-8 sequence is incomplete, only move the
+	// walker once they release them, and release the last of them only at the
+	// end of the key, so call [Walker.Finish] once the input is complete to
+	// learn whether all of it is a stored key. A Walker must not be used after
+	// the [Trie] it was created from has been modified; [Walker.Reset] it
+	// first.
+	Walker[TValue any] interface {
+		// Step advances the walker by one unit.
+		//
+		// Parameters:
+		//   - unit is the next byte of the key being matched.
+		//
+		// Returns:
+		//   - ok is `true` if the input so far is a stored key or a prefix of
+		//     one, or `false` once it has left the trie. A walker that has left
+		//     the trie stays out of it until reset.
+		Step(unit uint8) (ok bool)
+
+		// Finish marks the end of the input, releasing any units the transforms
+		// were holding back, after which [Walker.HasValue] and [Walker.Value]
+		// consider the whole input. Step fails after Finish until the walker
+		// is reset.
+		//
+		// Returns:
+		//   - ok is `true` if the whole input is a stored key or a prefix of
+		//     one, or `false` if it has left the trie.
+		Finish() (ok bool)
+
+		// HasValue reports whether the input so far is a stored key. Until
+		// [Walker.Finish] is called, this excludes any units the transforms
+		// are still holding back.
+		HasValue() bool
+
+		// Value returns the value stored at the current position, or the zero
+		// value if [Walker.HasValue] is `false`.
+		Value() (value TValue)
+
+		// CanContinue reports whether any stored key extends the input so far.
+		CanContinue() bool
+
+		// Reset moves the walker back to the root.
+		Reset()
+	}
+
+	// KeyCodec converts keys of a type without a built-in encoding to and from
+	// the path of units (bytes) they are stored under in a [Trie]. Keys are
+	// ordered byte-wise by their encodings, so a codec that should support
+	// ordered iteration, [SimpleTrie.Rank] or [Cursor.Seek] must encode keys
+	// such that their byte order matches the intended key order, and a codec
+	// that should support prefix queries must encode a key prefix as a prefix
+	// of the encodings of the keys it covers.
+	KeyCodec[TKey any] interface {
+		// Encode appends the path of a key to path. A key that cannot be
+		// encoded is never stored or found: [Trie.Add] ignores it and lookups
+		// report it as missing.
+		//
+		// Parameters:
+		//   - key is the key to encode.
+		//   - path is the buffer to append to.
+		//
+		// Returns:
+		//   - encoded is path with the encoded key appended.
+		//   - err is set if key cannot be encoded.
+		Encode(key TKey, path []uint8) (encoded []uint8, err error)
+
+		// Decode reconstructs a key from its (transformed) path.
+		//
+		// Parameters:
+		//   - path is the path of units the key is stored under.
+		//
+		// Returns:
+		//   - key is the decoded key.
+		//   - err is set if path is not a valid encoding.
+		Decode(path []uint8) (key TKey, err error)
+	}
+
 	// TransformFunc is used to transform a [TrieKey] for any normalization
 	// processes when performing a store or retrieval operation. Normalization
 	// is performed using a keyhole approach (one byte at a time with no context).
@@ -71,4 +252,50 @@ type (
 	//     value or comparing key values, or `false` to indicate that this byte
 	//     should be ignored entirely.
 	TransformFunc func(in byte) (out byte, use bool)
+
+	// RuneTransformer normalizes the runes of a key for a [Trie] created
+	// [WithRuneTransforms]. Unlike a [TransformFunc], it sees whole runes,
+	// may keep state across the runes of a key, and may emit any number of
+	// runes for each rune it is handed.
+	RuneTransformer interface {
+		// Reset discards any state before a new key is transformed.
+		Reset()
+
+		// Transform appends the runes to use in place of in to out.
+		//
+		// Parameters:
+		//   - in is the current rune of the key.
+		//   - out is the buffer to append to.
+		//
+		// Returns:
+		//   - out with any transformed runes appended.
+		Transform(in rune, out []rune) []rune
+
+		// Flush appends any runes still held back at the end of the key to
+		// out.
+		Flush(out []rune) []rune
+	}
+
+	// Transformer normalizes the bytes of a key for a [Trie] created
+	// [WithTransformers]. Unlike a [TransformFunc], it may keep state across
+	// the bytes of a key, and may emit any number of bytes for each byte it is
+	// handed, which allows it to see context such as the surrounding word.
+	Transformer interface {
+		// Reset discards any state before a new key is transformed.
+		Reset()
+
+		// Transform appends the bytes to use in place of in to out.
+		//
+		// Parameters:
+		//   - in is the current byte of the key.
+		//   - out is the buffer to append to.
+		//
+		// Returns:
+		//   - out with any transformed bytes appended.
+		Transform(in uint8, out []uint8) []uint8
+
+		// Flush appends any bytes still held back at the end of the key to
+		// out.
+		Flush(out []uint8) []uint8
+	}
 )
@@ -3,12 +3,19 @@ package tries
 import (
 	"encoding/binary"
 	"fmt"
+	"math"
+)
+
+const (
+	signBit32 = 1 << 31
+	signBit64 = 1 << 63
 )
 
 type (
-	converter[T TrieKey] interface {
+	converter[T any] interface {
 		Load(value T) error
 		Next() (value uint8, ok bool)
+		Decode(path []uint8) (value T, err error)
 	}
 
 	converterUInt8[T TrieKey] struct {
@@ -51,6 +58,16 @@ type (
 		value    [8]uint8
 	}
 
+	converterFloat32[T TrieKey] struct {
+		position uint8
+		value    [4]uint8
+	}
+
+	converterFloat64[T TrieKey] struct {
+		position uint8
+		value    [8]uint8
+	}
+
 	converterString[T TrieKey] struct {
 		position int
 		value    string
@@ -61,15 +78,24 @@ type (
 		value    []TItem
 	}
 
-	converterIntSlice[TItem ~uint16 | ~int16 | ~uint32 | ~int32 | ~uint64 | ~int64, TKey TrieKey] struct {
+	converterIntSlice[TItem ~uint16 | ~int16 | ~uint32 | ~int32 | ~uint64 | ~int64 | ~float32 | ~float64, TKey TrieKey] struct {
 		position     int
 		subConverter converter[TItem]
 		value        []TItem
 	}
 
-	converterTransforms[T TrieKey] struct {
+	converterCodec[T any] struct {
+		codec    KeyCodec[T]
+		buffer   []uint8
+		position int
+	}
+
+	converterTransforms[T any] struct {
 		subConverter converter[T]
-		transforms   []TransformFunc
+		pipeline     *transformPipeline
+		buffer       []uint8
+		position     int
+		done         bool
 	}
 )
 
@@ -90,6 +116,14 @@ func (this *converterUInt8[T]) Next() (value uint8, ok bool) {
 	return this.value, true
 }
 
+func (this *converterUInt8[T]) Decode(path []uint8) (value T, err error) {
+	if len(path) != 1 {
+		return value, fmt.Errorf("%w: expected 1 byte but found %d", ErrorBadTrieKey, len(path))
+	}
+
+	return any(path[0]).(T), nil
+}
+
 // ----- int8 -----
 func (this *converterInt8[T]) Load(value T) error {
 	this.position = 0
@@ -107,6 +141,14 @@ func (this *converterInt8[T]) Next() (value uint8, ok bool) {
 	return this.value, true
 }
 
+func (this *converterInt8[T]) Decode(path []uint8) (value T, err error) {
+	if len(path) != 1 {
+		return value, fmt.Errorf("%w: expected 1 byte but found %d", ErrorBadTrieKey, len(path))
+	}
+
+	return any(int8(path[0])).(T), nil //nolint:gosec // this casting is fine
+}
+
 // ----- uint16 -----
 func (this *converterUInt16[T]) Load(value T) error {
 	this.position = 0
@@ -125,6 +167,14 @@ func (this *converterUInt16[T]) Next() (value uint8, ok bool) {
 	return value, true
 }
 
+func (this *converterUInt16[T]) Decode(path []uint8) (value T, err error) {
+	if len(path) != 2 {
+		return value, fmt.Errorf("%w: expected 2 bytes but found %d", ErrorBadTrieKey, len(path))
+	}
+
+	return any(binary.BigEndian.Uint16(path)).(T), nil
+}
+
 // ----- int16 -----
 func (this *converterInt16[T]) Load(value T) error {
 	this.position = 0
@@ -143,6 +193,14 @@ func (this *converterInt16[T]) Next() (value uint8, ok bool) {
 	return value, true
 }
 
+func (this *converterInt16[T]) Decode(path []uint8) (value T, err error) {
+	if len(path) != 2 {
+		return value, fmt.Errorf("%w: expected 2 bytes but found %d", ErrorBadTrieKey, len(path))
+	}
+
+	return any(int16(binary.BigEndian.Uint16(path))).(T), nil //nolint:gosec // this casting is fine
+}
+
 // ----- uint32 -----
 func (this *converterUInt32[T]) Load(value T) error {
 	this.position = 0
@@ -161,6 +219,14 @@ func (this *converterUInt32[T]) Next() (value uint8, ok bool) {
 	return value, true
 }
 
+func (this *converterUInt32[T]) Decode(path []uint8) (value T, err error) {
+	if len(path) != 4 {
+		return value, fmt.Errorf("%w: expected 4 bytes but found %d", ErrorBadTrieKey, len(path))
+	}
+
+	return any(binary.BigEndian.Uint32(path)).(T), nil
+}
+
 // ----- int32 -----
 func (this *converterInt32[T]) Load(value T) error {
 	this.position = 0
@@ -179,6 +245,14 @@ func (this *converterInt32[T]) Next() (value uint8, ok bool) {
 	return value, true
 }
 
+func (this *converterInt32[T]) Decode(path []uint8) (value T, err error) {
+	if len(path) != 4 {
+		return value, fmt.Errorf("%w: expected 4 bytes but found %d", ErrorBadTrieKey, len(path))
+	}
+
+	return any(int32(binary.BigEndian.Uint32(path))).(T), nil //nolint:gosec // this casting is fine
+}
+
 // ----- uint64 -----
 func (this *converterUInt64[T]) Load(value T) error {
 	this.position = 0
@@ -197,6 +271,14 @@ func (this *converterUInt64[T]) Next() (value uint8, ok bool) {
 	return value, true
 }
 
+func (this *converterUInt64[T]) Decode(path []uint8) (value T, err error) {
+	if len(path) != 8 {
+		return value, fmt.Errorf("%w: expected 8 bytes but found %d", ErrorBadTrieKey, len(path))
+	}
+
+	return any(binary.BigEndian.Uint64(path)).(T), nil
+}
+
 // ----- int64 -----
 func (this *converterInt64[T]) Load(value T) error {
 	this.position = 0
@@ -215,6 +297,96 @@ func (this *converterInt64[T]) Next() (value uint8, ok bool) {
 	return value, true
 }
 
+func (this *converterInt64[T]) Decode(path []uint8) (value T, err error) {
+	if len(path) != 8 {
+		return value, fmt.Errorf("%w: expected 8 bytes but found %d", ErrorBadTrieKey, len(path))
+	}
+
+	return any(int64(binary.BigEndian.Uint64(path))).(T), nil //nolint:gosec // this casting is fine
+}
+
+// ----- float32 -----
+// Floats are stored with the sign bit flipped for positive values and every
+// bit flipped for negative ones, which orders their bits numerically.
+func (this *converterFloat32[T]) Load(value T) error {
+	this.position = 0
+	bits := math.Float32bits(any(value).(float32))
+	if bits&signBit32 != 0 {
+		bits = ^bits
+	} else {
+		bits |= signBit32
+	}
+
+	binary.BigEndian.PutUint32(this.value[:4], bits)
+	return nil
+}
+
+func (this *converterFloat32[T]) Next() (value uint8, ok bool) {
+	const fourBytes = 3
+	if this.position > fourBytes {
+		return 0, false
+	}
+
+	value = this.value[this.position]
+	this.position++
+	return value, true
+}
+
+func (this *converterFloat32[T]) Decode(path []uint8) (value T, err error) {
+	if len(path) != 4 {
+		return value, fmt.Errorf("%w: expected 4 bytes but found %d", ErrorBadTrieKey, len(path))
+	}
+
+	bits := binary.BigEndian.Uint32(path)
+	if bits&signBit32 != 0 {
+		bits &^= signBit32
+	} else {
+		bits = ^bits
+	}
+
+	return any(math.Float32frombits(bits)).(T), nil
+}
+
+// ----- float64 -----
+func (this *converterFloat64[T]) Load(value T) error {
+	this.position = 0
+	bits := math.Float64bits(any(value).(float64))
+	if bits&signBit64 != 0 {
+		bits = ^bits
+	} else {
+		bits |= signBit64
+	}
+
+	binary.BigEndian.PutUint64(this.value[:8], bits)
+	return nil
+}
+
+func (this *converterFloat64[T]) Next() (value uint8, ok bool) {
+	const eightBytes = 7
+	if this.position > eightBytes {
+		return 0, false
+	}
+
+	value = this.value[this.position]
+	this.position++
+	return value, true
+}
+
+func (this *converterFloat64[T]) Decode(path []uint8) (value T, err error) {
+	if len(path) != 8 {
+		return value, fmt.Errorf("%w: expected 8 bytes but found %d", ErrorBadTrieKey, len(path))
+	}
+
+	bits := binary.BigEndian.Uint64(path)
+	if bits&signBit64 != 0 {
+		bits &^= signBit64
+	} else {
+		bits = ^bits
+	}
+
+	return any(math.Float64frombits(bits)).(T), nil
+}
+
 // ----- string -----
 func (this *converterString[T]) Load(value T) error {
 	this.position = 0
@@ -237,6 +409,10 @@ func (this *converterString[T]) Next() (value uint8, ok bool) {
 	return value, true
 }
 
+func (this *converterString[T]) Decode(path []uint8) (value T, err error) {
+	return any(string(path)).(T), nil
+}
+
 // ----- []int8 -----
 func (this *converterInt8Slice[TItem, TKey]) Load(value TKey) error {
 	this.position = 0
@@ -259,6 +435,15 @@ func (this *converterInt8Slice[TItem, TKey]) Next() (value uint8, ok bool) {
 	return value, true
 }
 
+func (this *converterInt8Slice[TItem, TKey]) Decode(path []uint8) (value TKey, err error) {
+	items := make([]TItem, len(path))
+	for i, item := range path {
+		items[i] = TItem(item)
+	}
+
+	return any(items).(TKey), nil
+}
+
 // ----- []int(x) -----
 func (this *converterIntSlice[TItem, TKey]) Load(value TKey) error {
 	this.position = 0
@@ -294,30 +479,99 @@ func (this *converterIntSlice[TItem, TKey]) Next() (value uint8, ok bool) {
 	return value, true
 }
 
+func (this *converterIntSlice[TItem, TKey]) Decode(path []uint8) (value TKey, err error) {
+	var item TItem
+	width := binary.Size(item)
+	if len(path)%width != 0 {
+		return value, fmt.Errorf("%w: expected a multiple of %d bytes but found %d", ErrorBadTrieKey, width, len(path))
+	}
+
+	items := make([]TItem, 0, len(path)/width)
+	for ; len(path) > 0; path = path[width:] {
+		item, err = this.subConverter.Decode(path[:width])
+		if err != nil {
+			return value, err
+		}
+
+		items = append(items, item)
+	}
+
+	return any(items).(TKey), nil
+}
+
 // ----- transforms -----
 func (this *converterTransforms[T]) Load(value T) error {
+	this.pipeline.reset()
+	this.buffer = this.buffer[:0]
+	this.position = 0
+	this.done = false
 	return this.subConverter.Load(value)
 }
 
 func (this *converterTransforms[T]) Next() (value uint8, ok bool) {
-	for {
-		value, ok = this.subConverter.Next()
-		if !ok {
-			return value, ok
+	for this.position >= len(this.buffer) {
+		if this.done {
+			return 0, false
 		}
 
-		passes := true
-		for _, transform := range this.transforms {
-			value, passes = transform(value)
-			if !passes {
-				break
-			}
+		this.buffer = this.buffer[:0]
+		this.position = 0
+		if value, ok = this.subConverter.Next(); ok {
+			this.buffer = this.pipeline.push(value, this.buffer)
+		} else {
+			this.buffer = this.pipeline.flush(this.buffer)
+			this.done = true
 		}
+	}
+
+	value = this.buffer[this.position]
+	this.position++
+	return value, true
+}
+
+// Decode reconstructs a key from its transformed path. Transforms are not
+// reversible, so the result is the normalized form of the original key.
+func (this *converterTransforms[T]) Decode(path []uint8) (value T, err error) {
+	return this.subConverter.Decode(path)
+}
+
+// ----- codec -----
+func (this *converterCodec[T]) Load(value T) error {
+	this.position = 0
+	encoded, err := this.codec.Encode(value, this.buffer[:0])
+	if err != nil {
+		this.buffer = this.buffer[:0]
+		return err
+	}
+
+	this.buffer = encoded
+	return nil
+}
 
-		if passes {
-			return value, ok
+func (this *converterCodec[T]) Next() (value uint8, ok bool) {
+	if this.position >= len(this.buffer) {
+		return 0, false
+	}
+
+	value = this.buffer[this.position]
+	this.position++
+	return value, true
+}
+
+func (this *converterCodec[T]) Decode(path []uint8) (value T, err error) {
+	return this.codec.Decode(path)
+}
+
+func applyTransforms(transforms []TransformFunc, value uint8) (uint8, bool) {
+	passes := true
+	for _, transform := range transforms {
+		value, passes = transform(value)
+		if !passes {
+			return value, false
 		}
 	}
+
+	return value, true
 }
 
 func selectConverter[T TrieKey]() (converter converter[T], err error) {
@@ -339,6 +593,10 @@ func selectConverter[T TrieKey]() (converter converter[T], err error) {
 		return new(converterUInt64[T]), nil
 	case int64:
 		return new(converterInt64[T]), nil
+	case float32:
+		return new(converterFloat32[T]), nil
+	case float64:
+		return new(converterFloat64[T]), nil
 	case string:
 		return new(converterString[T]), nil
 	case []uint8:
@@ -357,14 +615,31 @@ func selectConverter[T TrieKey]() (converter converter[T], err error) {
 		return &converterIntSlice[uint64, T]{subConverter: new(converterUInt64[uint64])}, nil
 	case []int64:
 		return &converterIntSlice[int64, T]{subConverter: new(converterInt64[int64])}, nil
+	case []float32:
+		return &converterIntSlice[float32, T]{subConverter: new(converterFloat32[float32])}, nil
+	case []float64:
+		return &converterIntSlice[float64, T]{subConverter: new(converterFloat64[float64])}, nil
 	default:
 		return nil, fmt.Errorf("%w: no converter is defined for type %T", ErrorBadTrieKey, dummy)
 	}
 }
 
-func wrapConverter[T TrieKey](converter converter[T], transforms []TransformFunc) converter[T] {
+func newConverter[T TrieKey](stages []func() transformStage) (converter converter[T], err error) {
+	converter, err = selectConverter[T]()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stages) > 0 {
+		converter = wrapConverter(converter, stages)
+	}
+
+	return converter, nil
+}
+
+func wrapConverter[T any](converter converter[T], stages []func() transformStage) converter[T] {
 	return &converterTransforms[T]{
 		subConverter: converter,
-		transforms:   transforms,
+		pipeline:     newTransformPipeline(stages),
 	}
 }
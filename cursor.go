@@ -0,0 +1,188 @@
+package tries
+
+// simpleCursor walks a [SimpleTrie] in trie order. It keeps the chain of nodes
+// from the head down to its current position, along with the index of each
+// node within its parent, so that it can step in either direction without
+// re-walking from the head.
+type simpleCursor[TKey any, TValue any] struct {
+	trie    *SimpleTrie[TKey, TValue]
+	nodes   []*simpleNode[TKey, TValue] // nodes[0] is the head
+	indexes []int                       // indexes[i] is the position of nodes[i+1] within nodes[i].next
+	path    []uint8                     // path[i] is the key of nodes[i+1]
+	valid   bool
+}
+
+func newSimpleCursor[TKey any, TValue any](trie *SimpleTrie[TKey, TValue]) *simpleCursor[TKey, TValue] {
+	return &simpleCursor[TKey, TValue]{trie: trie}
+}
+
+func (this *simpleCursor[TKey, TValue]) First() bool {
+	this.reset()
+	return this.leftmost()
+}
+
+func (this *simpleCursor[TKey, TValue]) Last() bool {
+	this.reset()
+	return this.rightmost()
+}
+
+func (this *simpleCursor[TKey, TValue]) Seek(key TKey) bool {
+	this.reset()
+	if err := this.trie.converter.Load(key); err != nil {
+		return false
+	}
+
+	for k, ok := this.trie.converter.Next(); ok; k, ok = this.trie.converter.Next() {
+		node := this.current()
+		index := node.lowerBound(k)
+		if index >= len(node.next) {
+			return this.skip()
+		}
+
+		this.push(index)
+		if node.next[index].key != k {
+			return this.leftmost()
+		}
+	}
+
+	return this.leftmost()
+}
+
+func (this *simpleCursor[TKey, TValue]) SeekPrefix(prefix TKey) bool {
+	this.reset()
+	if err := this.trie.converter.Load(prefix); err != nil {
+		return false
+	}
+
+	for k, ok := this.trie.converter.Next(); ok; k, ok = this.trie.converter.Next() {
+		node := this.current()
+		index := node.lowerBound(k)
+		if index >= len(node.next) || node.next[index].key != k {
+			this.valid = false
+			return false
+		}
+
+		this.push(index)
+	}
+
+	return this.leftmost()
+}
+
+func (this *simpleCursor[TKey, TValue]) Next() bool {
+	if !this.valid {
+		return false
+	}
+
+	if len(this.current().next) > 0 {
+		this.push(0)
+		return this.leftmost()
+	}
+
+	return this.skip()
+}
+
+func (this *simpleCursor[TKey, TValue]) Prev() bool {
+	if !this.valid {
+		return false
+	}
+
+	for len(this.indexes) > 0 {
+		index := this.pop()
+		if index > 0 {
+			this.push(index - 1)
+			return this.rightmost()
+		}
+
+		if this.current().hasValue {
+			return true
+		}
+	}
+
+	this.valid = false
+	return false
+}
+
+func (this *simpleCursor[TKey, TValue]) Valid() bool {
+	return this.valid
+}
+
+func (this *simpleCursor[TKey, TValue]) Key() (key TKey) {
+	if !this.valid {
+		return key
+	}
+
+	key, _ = this.trie.key(this.path, this.current())
+	return key
+}
+
+func (this *simpleCursor[TKey, TValue]) Value() (value TValue) {
+	if !this.valid {
+		return value
+	}
+
+	return this.current().value
+}
+
+func (this *simpleCursor[TKey, TValue]) reset() {
+	this.nodes = append(this.nodes[:0], &this.trie.head)
+	this.indexes = this.indexes[:0]
+	this.path = this.path[:0]
+	this.valid = false
+}
+
+func (this *simpleCursor[TKey, TValue]) current() *simpleNode[TKey, TValue] {
+	return this.nodes[len(this.nodes)-1]
+}
+
+func (this *simpleCursor[TKey, TValue]) push(index int) {
+	node := &this.current().next[index]
+	this.nodes = append(this.nodes, node)
+	this.indexes = append(this.indexes, index)
+	this.path = append(this.path, node.key)
+}
+
+func (this *simpleCursor[TKey, TValue]) pop() (index int) {
+	index = this.indexes[len(this.indexes)-1]
+	this.nodes = this.nodes[:len(this.nodes)-1]
+	this.indexes = this.indexes[:len(this.indexes)-1]
+	this.path = this.path[:len(this.path)-1]
+	return index
+}
+
+// leftmost moves to the first value at or beneath the current node.
+func (this *simpleCursor[TKey, TValue]) leftmost() bool {
+	for !this.current().hasValue {
+		if len(this.current().next) == 0 {
+			return this.skip()
+		}
+
+		this.push(0)
+	}
+
+	this.valid = true
+	return true
+}
+
+// rightmost moves to the last value at or beneath the current node.
+func (this *simpleCursor[TKey, TValue]) rightmost() bool {
+	for len(this.current().next) > 0 {
+		this.push(len(this.current().next) - 1)
+	}
+
+	this.valid = this.current().hasValue
+	return this.valid
+}
+
+// skip moves to the first value after the entire subtree of the current node.
+func (this *simpleCursor[TKey, TValue]) skip() bool {
+	for len(this.indexes) > 0 {
+		index := this.pop() + 1
+		if index < len(this.current().next) {
+			this.push(index)
+			return this.leftmost()
+		}
+	}
+
+	this.valid = false
+	return false
+}
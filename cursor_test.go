@@ -0,0 +1,89 @@
+package tries
+
+import (
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func newCursorTestTrie() *SimpleTrie[string, int] {
+	trie, _ := NewTrieWithOptions[string, int]()
+	trie.Add("b", 1)
+	trie.Add("abc", 2)
+	trie.Add("a", 3)
+	trie.Add("ab", 4)
+	trie.Add("ca", 5)
+	trie.Add("cb", 6)
+	return trie
+}
+
+func Test_Cursor_ForwardAndBackward(t *testing.T) {
+	and := assertions.New(t)
+	cursor := newCursorTestTrie().Cursor()
+
+	var forward []string
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		forward = append(forward, cursor.Key())
+	}
+
+	var backward []string
+	for ok := cursor.Last(); ok; ok = cursor.Prev() {
+		backward = append(backward, cursor.Key())
+	}
+
+	and.So(forward, should.Equal, []string{"a", "ab", "abc", "b", "ca", "cb"})
+	and.So(backward, should.Equal, []string{"cb", "ca", "b", "abc", "ab", "a"})
+	and.So(cursor.Valid(), should.BeFalse)
+	and.So(cursor.Key(), should.Equal, "")
+}
+
+func Test_Cursor_Seek(t *testing.T) {
+	cursor := newCursorTestTrie().Cursor()
+
+	testTable := map[string]struct {
+		Input    string
+		Expected string
+		OK       bool
+	}{
+		"empty":        {Input: "", Expected: "a", OK: true},
+		"exact":        {Input: "ab", Expected: "ab", OK: true},
+		"between":      {Input: "abd", Expected: "b", OK: true},
+		"extension":    {Input: "bb", Expected: "ca", OK: true},
+		"inner-node":   {Input: "c", Expected: "ca", OK: true},
+		"past-the-end": {Input: "d", Expected: "", OK: false},
+		"last":         {Input: "cb", Expected: "cb", OK: true},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			and := assertions.New(t)
+			and.So(cursor.Seek(testCase.Input), should.Equal, testCase.OK)
+			and.So(cursor.Key(), should.Equal, testCase.Expected)
+		})
+	}
+}
+
+func Test_Cursor_SeekPrefix(t *testing.T) {
+	and := assertions.New(t)
+	cursor := newCursorTestTrie().Cursor()
+
+	and.So(cursor.SeekPrefix("c"), should.BeTrue)
+	and.So(cursor.Key(), should.Equal, "ca")
+	and.So(cursor.Value(), should.Equal, 5)
+	and.So(cursor.Prev(), should.BeTrue)
+	and.So(cursor.Key(), should.Equal, "b")
+
+	and.So(cursor.SeekPrefix("ac"), should.BeFalse)
+	and.So(cursor.Next(), should.BeFalse)
+}
+
+func Test_Cursor_Empty(t *testing.T) {
+	and := assertions.New(t)
+	trie, _ := NewTrieWithOptions[uint16, int]()
+	cursor := trie.Cursor()
+
+	and.So(cursor.First(), should.BeFalse)
+	and.So(cursor.Last(), should.BeFalse)
+	and.So(cursor.Seek(0), should.BeFalse)
+}
@@ -3,5 +3,9 @@ package tries
 import "errors"
 
 var (
-	ErrorBadTrieKey = errors.New("unable to create Trie with bad key type")
+	ErrorBadTrieKey           = errors.New("unable to create Trie with bad key type")
+	ErrorBadRanking           = errors.New("unable to create Trie with a ranking for a different value type")
+	ErrorUnsupportedTrie      = errors.New("unable to use a Trie implementation other than SimpleTrie")
+	ErrorBadUnitBits          = errors.New("unable to create Trie with path units of other than 1, 2, 4 or 8 bits")
+	ErrorUnsupportedTransform = errors.New("unable to use a Trie whose transforms may hold units back")
 )
@@ -0,0 +1,148 @@
+package tries
+
+// UnitCosts charges one for every edit. If Transpositions is set, swapping two
+// adjacent units also counts as a single edit, which measures the optimal
+// string alignment (restricted Damerau-Levenshtein) distance instead of the
+// Levenshtein distance.
+type UnitCosts struct {
+	Transpositions bool
+}
+
+func (this UnitCosts) Insert(uint8) (cost int) {
+	return 1
+}
+
+func (this UnitCosts) Delete(uint8) (cost int) {
+	return 1
+}
+
+func (this UnitCosts) Substitute(uint8, uint8) (cost int) {
+	return 1
+}
+
+func (this UnitCosts) Transpose(uint8, uint8) (cost int, allowed bool) {
+	return 1, this.Transpositions
+}
+
+// fuzzySearch walks a trie while maintaining one row of the edit distance
+// dynamic programming matrix per level of depth, where row[i] is the cost of
+// turning the first i units of the query into the path so far. Any branch
+// whose row has no cell within the budget cannot recover, unless a
+// transposition spanning the next unit could still bring it back, so it is
+// pruned.
+type fuzzySearch[TKey any, TValue any] struct {
+	trie    *SimpleTrie[TKey, TValue]
+	costs   EditCosts
+	query   []uint8
+	maxCost int
+	path    []uint8
+	rows    [][]int // rows[depth] is the row after consuming path[:depth]
+}
+
+func newFuzzySearch[TKey any, TValue any](trie *SimpleTrie[TKey, TValue], key TKey, maxCost int, costs EditCosts) (*fuzzySearch[TKey, TValue], error) {
+	query, err := loadPath(trie.converter, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	search := &fuzzySearch[TKey, TValue]{
+		trie:    trie,
+		costs:   costs,
+		query:   query,
+		maxCost: maxCost,
+	}
+
+	first := search.row(0)
+	for i := 1; i < len(first); i++ {
+		first[i] = first[i-1] + costs.Delete(search.query[i-1])
+	}
+
+	return search, nil
+}
+
+func (this *fuzzySearch[TKey, TValue]) walk(node *simpleNode[TKey, TValue], yield func(TKey, TValue) bool) bool {
+	depth := len(this.path)
+	row := this.rows[depth]
+	if node.hasValue && row[len(this.query)] <= this.maxCost {
+		key, err := this.trie.key(this.path, node)
+		if err == nil && !yield(key, node.value) {
+			return false
+		}
+	}
+
+	for i := range node.next {
+		next := &node.next[i]
+		if !this.advance(depth, next.key) {
+			continue
+		}
+
+		this.path = append(this.path, next.key)
+		if !this.walk(next, yield) {
+			return false
+		}
+
+		this.path = this.path[:depth]
+	}
+
+	return true
+}
+
+// advance fills the row for depth+1 after consuming unit, and reports whether
+// the branch is still worth exploring.
+func (this *fuzzySearch[TKey, TValue]) advance(depth int, unit uint8) (viable bool) {
+	row := this.rows[depth]
+	nextRow := this.row(depth + 1)
+	nextRow[0] = row[0] + this.costs.Insert(unit)
+	viable = nextRow[0] <= this.maxCost
+	for j := 1; j <= len(this.query); j++ {
+		substitution := row[j-1]
+		if this.query[j-1] != unit {
+			substitution += this.costs.Substitute(this.query[j-1], unit)
+		}
+
+		nextRow[j] = min(substitution, row[j]+this.costs.Insert(unit), nextRow[j-1]+this.costs.Delete(this.query[j-1]))
+		if depth > 0 && j > 1 && this.query[j-2] == unit && this.query[j-1] == this.path[depth-1] {
+			if cost, allowed := this.costs.Transpose(this.query[j-2], this.query[j-1]); allowed {
+				nextRow[j] = min(nextRow[j], this.rows[depth-1][j-2]+cost)
+			}
+		}
+
+		viable = viable || nextRow[j] <= this.maxCost || this.transposable(row[j-1], j, unit)
+	}
+
+	return viable
+}
+
+// transposable reports whether the unit after the provided one could complete a
+// transposition that keeps the branch within budget, even though no cell of
+// the row reached through unit is within it.
+func (this *fuzzySearch[TKey, TValue]) transposable(before int, j int, unit uint8) bool {
+	if j >= len(this.query) || this.query[j] != unit || before > this.maxCost {
+		return false
+	}
+
+	cost, allowed := this.costs.Transpose(this.query[j-1], this.query[j])
+	return allowed && before+cost <= this.maxCost
+}
+
+// row returns the reusable row for the provided depth.
+func (this *fuzzySearch[TKey, TValue]) row(depth int) []int {
+	for len(this.rows) <= depth {
+		this.rows = append(this.rows, make([]int, len(this.query)+1))
+	}
+
+	return this.rows[depth]
+}
+
+// loadPath appends the units produced by converter for key to path.
+func loadPath[TKey any](converter converter[TKey], key TKey, path []uint8) ([]uint8, error) {
+	if err := converter.Load(key); err != nil {
+		return path, err
+	}
+
+	for unit, ok := converter.Next(); ok; unit, ok = converter.Next() {
+		path = append(path, unit)
+	}
+
+	return path, nil
+}
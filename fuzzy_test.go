@@ -0,0 +1,211 @@
+package tries
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func Test_SimpleTrie_FuzzyFind(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int](WithTransforms(func(in byte) (out byte, use bool) {
+		if in >= 'A' && in <= 'Z' {
+			return in - 'A' + 'a', true
+		}
+
+		return in, true
+	}))
+	trie.Add("Main St", 1)
+	trie.Add("Street", 2)
+	trie.Add("Strait", 3)
+	trie.Add("Stream", 4)
+	trie.Add("", 5)
+
+	testTable := map[string]struct {
+		Input    string
+		MaxEdits int
+		Expected map[string]int
+	}{
+		"exact":        {Input: "street", MaxEdits: 0, Expected: map[string]int{"street": 2}},
+		"deletion":     {Input: "Stret", MaxEdits: 1, Expected: map[string]int{"street": 2}},
+		"insertion":    {Input: "Streeet", MaxEdits: 1, Expected: map[string]int{"street": 2}},
+		"substitution": {Input: "Strezt", MaxEdits: 1, Expected: map[string]int{"street": 2}},
+		"transposed":   {Input: "Mian St", MaxEdits: 2, Expected: map[string]int{"main st": 1}},
+		"several":      {Input: "Stre", MaxEdits: 2, Expected: map[string]int{"street": 2, "stream": 4}},
+		"empty":        {Input: "", MaxEdits: 0, Expected: map[string]int{"": 5}},
+		"negative":     {Input: "Street", MaxEdits: -1, Expected: map[string]int{}},
+		"not-in-data":  {Input: "Avenue", MaxEdits: 2, Expected: map[string]int{}},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			actual := make(map[string]int)
+			for key, value := range trie.FuzzyFind(testCase.Input, testCase.MaxEdits) {
+				actual[key] = value
+			}
+
+			assertions.New(t).So(actual, should.Equal, testCase.Expected)
+		})
+	}
+}
+
+func Test_SimpleTrie_FuzzyFind_StopsEarly(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int]()
+	trie.Add("aa", 1)
+	trie.Add("ab", 2)
+	trie.Add("ac", 3)
+
+	count := 0
+	for range trie.FuzzyFind("a", 1) {
+		count++
+		break
+	}
+
+	assertions.New(t).So(count, should.Equal, 1)
+}
+
+func Test_SimpleTrie_FuzzyFindCosts_Transpositions(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int]()
+	trie.Add("the", 1)
+	trie.Add("then", 2)
+	trie.Add("tea", 3)
+
+	collect := func(costs EditCosts, maxCost int) map[string]int {
+		actual := make(map[string]int)
+		for key, value := range trie.FuzzyFindCosts("teh", maxCost, costs) {
+			actual[key] = value
+		}
+
+		return actual
+	}
+
+	and := assertions.New(t)
+	and.So(collect(UnitCosts{}, 1), should.Equal, map[string]int{"tea": 3})
+	and.So(collect(UnitCosts{Transpositions: true}, 1), should.Equal, map[string]int{"the": 1, "tea": 3})
+	and.So(collect(UnitCosts{Transpositions: true}, 2), should.Equal, map[string]int{"the": 1, "then": 2, "tea": 3})
+}
+
+func Test_SimpleTrie_FuzzyFindCosts_Weighted(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int]()
+	trie.Add("street", 1)
+	trie.Add("strert", 2)
+	trie.Add("stweet", 3)
+
+	and := assertions.New(t)
+	actual := make(map[string]int)
+	for key, value := range trie.FuzzyFindCosts("strwet", 1, keyboardCosts{}) {
+		actual[key] = value
+	}
+
+	and.So(actual, should.Equal, map[string]int{"street": 1}) // w and e are neighbors, r and w are not
+
+	actual = make(map[string]int)
+	for key, value := range trie.FuzzyFindCosts("tsreet", 1, keyboardCosts{}) {
+		actual[key] = value
+	}
+
+	and.So(actual, should.Equal, map[string]int{"street": 1}) // reachable only through a cheap transposition
+}
+
+func Test_SimpleTrie_FuzzyFindCosts_MatchesBruteForce(t *testing.T) {
+	random := rand.New(rand.NewSource(7))
+	word := func() string {
+		units := make([]byte, random.Intn(6))
+		for i := range units {
+			units[i] = "abc"[random.Intn(3)]
+		}
+
+		return string(units)
+	}
+
+	trie, _ := NewTrieWithOptions[string, int]()
+	var words []string
+	for i := 0; i < 300; i++ {
+		key := word()
+		if trie.Add(key, i) {
+			words = append(words, key)
+		}
+	}
+
+	and := assertions.New(t)
+	for _, costs := range []EditCosts{UnitCosts{}, UnitCosts{Transpositions: true}, keyboardCosts{}} {
+		for i := 0; i < 50; i++ {
+			query := word()
+			actual := make(map[string]bool)
+			for key := range trie.FuzzyFindCosts(query, 2, costs) {
+				actual[key] = true
+			}
+
+			expected := make(map[string]bool)
+			for _, key := range words {
+				if editCost(query, key, costs) <= 2 {
+					expected[key] = true
+				}
+			}
+
+			and.So(actual, should.Equal, expected)
+		}
+	}
+}
+
+// keyboardCosts makes substituting neighboring keys and transposing cheap, and
+// everything else expensive.
+type keyboardCosts struct{}
+
+func (keyboardCosts) Insert(uint8) int {
+	return 2
+}
+
+func (keyboardCosts) Delete(uint8) int {
+	return 2
+}
+
+func (keyboardCosts) Substitute(from, to uint8) int {
+	neighbors := map[uint8]string{'w': "qe", 'e': "wr", 'r': "et", 'a': "sb", 'b': "ac", 'c': "b"}
+	if strings.IndexByte(neighbors[from], to) >= 0 {
+		return 1
+	}
+
+	return 3
+}
+
+func (keyboardCosts) Transpose(uint8, uint8) (int, bool) {
+	return 1, true
+}
+
+// editCost is a reference optimal string alignment distance over a full
+// matrix.
+func editCost(query, key string, costs EditCosts) int {
+	matrix := make([][]int, len(query)+1)
+	for i := range matrix {
+		matrix[i] = make([]int, len(key)+1)
+	}
+
+	for i := 1; i <= len(query); i++ {
+		matrix[i][0] = matrix[i-1][0] + costs.Delete(query[i-1])
+	}
+
+	for j := 1; j <= len(key); j++ {
+		matrix[0][j] = matrix[0][j-1] + costs.Insert(key[j-1])
+	}
+
+	for i := 1; i <= len(query); i++ {
+		for j := 1; j <= len(key); j++ {
+			substitution := matrix[i-1][j-1]
+			if query[i-1] != key[j-1] {
+				substitution += costs.Substitute(query[i-1], key[j-1])
+			}
+
+			matrix[i][j] = min(substitution, matrix[i-1][j]+costs.Delete(query[i-1]), matrix[i][j-1]+costs.Insert(key[j-1]))
+			if i > 1 && j > 1 && query[i-1] == key[j-2] && query[i-2] == key[j-1] {
+				if cost, allowed := costs.Transpose(query[i-2], query[i-1]); allowed {
+					matrix[i][j] = min(matrix[i][j], matrix[i-2][j-2]+cost)
+				}
+			}
+		}
+	}
+
+	return matrix[len(query)][len(key)]
+}
@@ -0,0 +1,230 @@
+package tries
+
+import "fmt"
+
+// globToken is a single element of a compiled glob pattern. A star matches
+// any run of units, including none; every other token matches exactly one
+// unit from its set.
+type globToken struct {
+	star  bool
+	units [4]uint64
+}
+
+// compileGlob parses a glob pattern, where '?' matches any single unit, '*'
+// matches any run of units, '[a-z0-9_]' matches one unit from a class ('!' or
+// '^' after the opening bracket negates the class) and '\' escapes the next
+// unit. A '[' that is never closed is taken literally. Literal units, and each
+// member of a class, are passed through the provided stages, so that a pattern
+// is normalized just like a key. Class members that do not come out as a
+// single unit are left out of the class.
+func compileGlob(pattern string, stages []func() transformStage) (tokens []globToken) {
+	pipeline := newTransformPipeline(stages)
+	members := newTransformPipeline(stages)
+	var literals []uint8
+	for i := 0; i < len(pattern); i++ {
+		var token globToken
+		switch unit := pattern[i]; unit {
+		case '*':
+			token.star = true
+		case '?':
+			token.units = [4]uint64{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)}
+		case '[':
+			var end int
+			if token.units, end = compileGlobClass(pattern, i, members); end > i {
+				i = end
+				break
+			}
+
+			literals = pipeline.push(unit, literals[:0])
+			tokens = appendGlobLiterals(tokens, literals)
+			continue
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				unit = pattern[i]
+			}
+
+			fallthrough
+		default:
+			literals = pipeline.push(unit, literals[:0])
+			tokens = appendGlobLiterals(tokens, literals)
+			continue
+		}
+
+		// Any partial rune held back by the pipeline is taken literally.
+		literals = pipeline.flush(literals[:0])
+		tokens = appendGlobLiterals(tokens, literals)
+		if token.star && len(tokens) > 0 && tokens[len(tokens)-1].star {
+			continue
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	literals = pipeline.flush(literals[:0])
+	return appendGlobLiterals(tokens, literals)
+}
+
+func appendGlobLiterals(tokens []globToken, literals []uint8) []globToken {
+	for _, unit := range literals {
+		var token globToken
+		token.set(unit)
+		tokens = append(tokens, token)
+	}
+
+	return tokens
+}
+
+// compileGlobClass parses the class starting at pattern[start], returning the
+// index of its closing ']', or start if the class is never closed.
+func compileGlobClass(pattern string, start int, members *transformPipeline) (units [4]uint64, end int) {
+	var class globToken
+	var transformed []uint8
+	member := func(unit uint8) {
+		members.reset()
+		transformed = members.flush(members.push(unit, transformed[:0]))
+		if len(transformed) == 1 {
+			class.set(transformed[0])
+		}
+	}
+
+	i := start + 1
+	negated := i < len(pattern) && (pattern[i] == '!' || pattern[i] == '^')
+	if negated {
+		i++
+	}
+
+	for first := i; i < len(pattern); i++ {
+		if pattern[i] == ']' && i > first {
+			if negated {
+				for j := range class.units {
+					class.units[j] = ^class.units[j]
+				}
+			}
+
+			return class.units, i
+		}
+
+		low := pattern[i]
+		if i+2 < len(pattern) && pattern[i+1] == '-' && pattern[i+2] != ']' {
+			high := pattern[i+2]
+			for unit := int(low); unit <= int(high); unit++ {
+				member(uint8(unit))
+			}
+
+			i += 2
+			continue
+		}
+
+		member(low)
+	}
+
+	return units, start
+}
+
+func (this *globToken) set(unit uint8) {
+	this.units[unit/64] |= 1 << (unit % 64)
+}
+
+func (this *globToken) matches(unit uint8) bool {
+	return this.star || this.units[unit/64]&(1<<(unit%64)) != 0
+}
+
+// globSearch walks a trie in lockstep with a glob pattern. The set of pattern
+// positions that are still alive is carried down each branch, and a branch is
+// abandoned as soon as that set is empty.
+type globSearch[TKey any, TValue any] struct {
+	trie   *SimpleTrie[TKey, TValue]
+	tokens []globToken
+	path   []uint8
+	states [][]bool // states[depth][i] is set if the pattern has matched path[:depth] up to tokens[i]
+}
+
+func newGlobSearch[TKey any, TValue any](trie *SimpleTrie[TKey, TValue], pattern string) (*globSearch[TKey, TValue], error) {
+	for _, stage := range newTransformPipeline(trie.stages).stages {
+		if !stage.aligned() {
+			return nil, fmt.Errorf("%w: unable to match patterns against units transformed by %s", ErrorUnsupportedTransform, stage.option())
+		}
+	}
+
+	return &globSearch[TKey, TValue]{trie: trie, tokens: compileGlob(pattern, trie.stages)}, nil
+}
+
+// begin creates a search for the same tokens with its own path and states, so
+// that every range over the results (even within another) walks independently.
+func (this *globSearch[TKey, TValue]) begin() *globSearch[TKey, TValue] {
+	search := &globSearch[TKey, TValue]{trie: this.trie, tokens: this.tokens}
+	first := search.state(0)
+	first[0] = true
+	search.close(first)
+	return search
+}
+
+func (this *globSearch[TKey, TValue]) walk(node *simpleNode[TKey, TValue], yield func(TKey, TValue) bool) bool {
+	depth := len(this.path)
+	state := this.states[depth]
+	if node.hasValue && state[len(this.tokens)] {
+		key, err := this.trie.key(this.path, node)
+		if err == nil && !yield(key, node.value) {
+			return false
+		}
+	}
+
+	for i := range node.next {
+		next := &node.next[i]
+		if !this.advance(depth, next.key) {
+			continue
+		}
+
+		this.path = append(this.path, next.key)
+		if !this.walk(next, yield) {
+			return false
+		}
+
+		this.path = this.path[:depth]
+	}
+
+	return true
+}
+
+// advance fills the state for depth+1 after consuming unit, and reports
+// whether any pattern position survived.
+func (this *globSearch[TKey, TValue]) advance(depth int, unit uint8) (alive bool) {
+	state := this.states[depth]
+	nextState := this.state(depth + 1)
+	clear(nextState)
+	for i, token := range this.tokens {
+		if !state[i] || !token.matches(unit) {
+			continue
+		}
+
+		if token.star {
+			nextState[i] = true
+		} else {
+			nextState[i+1] = true
+		}
+
+		alive = true
+	}
+
+	this.close(nextState)
+	return alive
+}
+
+// close marks every position reachable by skipping over stars.
+func (this *globSearch[TKey, TValue]) close(state []bool) {
+	for i, token := range this.tokens {
+		if state[i] && token.star {
+			state[i+1] = true
+		}
+	}
+}
+
+// state returns the reusable state for the provided depth.
+func (this *globSearch[TKey, TValue]) state(depth int) []bool {
+	for len(this.states) <= depth {
+		this.states = append(this.states, make([]bool, len(this.tokens)+1))
+	}
+
+	return this.states[depth]
+}
@@ -0,0 +1,173 @@
+package tries
+
+import (
+	"path"
+	"slices"
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func Test_SimpleTrie_Match(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int]()
+	keys := []string{"api/users", "api/posts", "api/comments", "api/users/42", "flag-a1", "flag-b2", "flag-*", "", "[x]"}
+	for i, key := range keys {
+		trie.Add(key, i)
+	}
+
+	testTable := map[string]struct {
+		Pattern  string
+		Expected []string
+	}{
+		"literal":         {Pattern: "api/posts", Expected: []string{"api/posts"}},
+		"star":            {Pattern: "api/*", Expected: []string{"api/comments", "api/posts", "api/users", "api/users/42"}},
+		"star-middle":     {Pattern: "api/*s", Expected: []string{"api/comments", "api/posts", "api/users"}},
+		"question":        {Pattern: "flag-??", Expected: []string{"flag-a1", "flag-b2"}},
+		"class":           {Pattern: "flag-[a-a][0-9]", Expected: []string{"flag-a1"}},
+		"negated-class":   {Pattern: "flag-[!a]*", Expected: []string{"flag-*", "flag-b2"}},
+		"escaped":         {Pattern: `flag-\*`, Expected: []string{"flag-*"}},
+		"unclosed-class":  {Pattern: "[x", Expected: nil},
+		"literal-bracket": {Pattern: `\[x]`, Expected: []string{"[x]"}},
+		"empty":           {Pattern: "", Expected: []string{""}},
+		"everything":      {Pattern: "**", Expected: []string{"", "[x]", "api/comments", "api/posts", "api/users", "api/users/42", "flag-*", "flag-a1", "flag-b2"}},
+		"not-in-data":     {Pattern: "web/*", Expected: nil},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			matches, err := trie.Match(testCase.Pattern)
+			assertions.New(t).So(err, should.BeNil)
+
+			var actual []string
+			for key := range matches {
+				actual = append(actual, key)
+			}
+
+			assertions.New(t).So(actual, should.Equal, testCase.Expected)
+		})
+	}
+}
+
+func Test_SimpleTrie_Match_AgreesWithPathMatch(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int]()
+	keys := []string{"a", "ab", "abc", "abcabc", "bca", "cab", "aaa", "b-c", ""}
+	for i, key := range keys {
+		trie.Add(key, i)
+	}
+
+	and := assertions.New(t)
+	for _, pattern := range []string{"a*", "*c", "?b*", "*a*a*", "[ab]*[^a]", "[a-c]-?", "a?c*", "*"} {
+		matches, err := trie.Match(pattern)
+		and.So(err, should.BeNil)
+
+		actual := make(map[string]bool)
+		for key := range matches {
+			actual[key] = true
+		}
+
+		expected := make(map[string]bool)
+		for _, key := range keys {
+			if matched, _ := path.Match(pattern, key); matched {
+				expected[key] = true
+			}
+		}
+
+		and.So(actual, should.Equal, expected)
+	}
+}
+
+func Test_SimpleTrie_Match_WithTransform(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int](WithTransforms(func(in byte) (out byte, use bool) {
+		if in >= 'A' && in <= 'Z' {
+			return in - 'A' + 'a', true
+		}
+
+		return in, true
+	}))
+	trie.Add("API/Users", 1)
+
+	matches, err := trie.Match("Api/U*")
+	assertions.New(t).So(err, should.BeNil)
+
+	var actual []string
+	for key := range matches {
+		actual = append(actual, key)
+	}
+
+	assertions.New(t).So(actual, should.Equal, []string{"api/users"})
+}
+
+func Test_SimpleTrie_Match_TransformsClasses(t *testing.T) {
+	testTable := map[string]struct {
+		Options  []Option
+		Pattern  string
+		Expected []string
+	}{
+		"transform-class":   {Options: []Option{WithTransforms(ASCIILower)}, Pattern: "[A-Z]*", Expected: []string{"alpha", "beta"}},
+		"transform-negated": {Options: []Option{WithTransforms(ASCIILower)}, Pattern: "[!A]*", Expected: []string{"1st", "beta", "Älpha"}},
+		"fold-class":        {Options: []Option{WithRuneTransforms(CaseFold)}, Pattern: "[A-B]?[T-T]*", Expected: []string{"beta"}},
+		"fold-literal":      {Options: []Option{WithRuneTransforms(CaseFold)}, Pattern: "ÄLPHA*", Expected: []string{"älpha"}},
+		"strip-literal":     {Options: []Option{WithRuneTransforms(StripDiacritics)}, Pattern: "BE?Á", Expected: []string{"BETA"}},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			trie, _ := NewTrieWithOptions[string, int](testCase.Options...)
+			for i, key := range []string{"Alpha", "BETA", "1st", "Älpha"} {
+				trie.Add(key, i)
+			}
+
+			matches, err := trie.Match(testCase.Pattern)
+			assertions.New(t).So(err, should.BeNil)
+
+			var actual []string
+			for key := range matches {
+				actual = append(actual, key)
+			}
+
+			assertions.New(t).So(actual, should.Equal, testCase.Expected)
+		})
+	}
+}
+
+func Test_SimpleTrie_Match_UnalignedTransforms(t *testing.T) {
+	testTable := map[string]struct {
+		Option Option
+		Named  string
+	}{
+		"transformer":     {Option: WithTransformers(StripLeadingZeros), Named: "WithTransformers"},
+		"rune-normalizer": {Option: WithRuneTransforms(NFC), Named: "WithRuneTransforms"},
+		"unit-bits":       {Option: WithUnitBits(4), Named: "WithUnitBits"},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			trie, _ := NewTrieWithOptions[string, int](testCase.Option)
+			matches, err := trie.Match("*")
+			assertions.New(t).So(matches, should.BeNil)
+			assertions.New(t).So(err, should.Wrap, ErrorUnsupportedTransform)
+			assertions.New(t).So(err.Error(), should.ContainSubstring, testCase.Named)
+		})
+	}
+}
+
+func Test_SimpleTrie_Match_NestedRanges(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int]()
+	for i, key := range []string{"ab", "abc", "b", "bcd"} {
+		trie.Add(key, i)
+	}
+
+	matches, _ := trie.Match("*b*")
+	var outer, inner []string
+	for key := range matches {
+		outer = append(outer, key)
+		for key := range matches {
+			inner = append(inner, key)
+		}
+	}
+
+	expected := []string{"ab", "abc", "b", "bcd"}
+	assertions.New(t).So(outer, should.Equal, expected)
+	assertions.New(t).So(inner, should.Equal, slices.Concat(expected, expected, expected, expected))
+}
@@ -6,3 +6,5 @@ require (
 	github.com/smarty/assertions v1.16.0
 	github.com/smarty/benchy v1.0.2
 )
+
+require golang.org/x/text v0.41.0
@@ -0,0 +1,196 @@
+package tries
+
+import (
+	"bytes"
+	"iter"
+	"strings"
+)
+
+// HostTable maps hostnames, and wildcard patterns such as *.example.com, to
+// values and finds the most specific entry matching a hostname, as a TLS
+// certificate selector or an egress allowlist does. Hostnames are matched
+// without regard to ASCII case and to any trailing dot, and are stored with
+// their labels reversed (com.example.api), so that every subdomain of a
+// domain shares its path.
+//
+// A wildcard replaces the leftmost label of a pattern and, as in certificate
+// selection, matches exactly one label in its place, so *.example.com matches
+// api.example.com, but neither v1.api.example.com nor example.com itself. A
+// table created by [NewMultiLabelHostTable] instead lets a wildcard match one
+// or more labels, as an egress allowlist covering every subdomain does. A lone
+// * matches every hostname in either case. An exact entry takes precedence
+// over any wildcard, and a longer wildcard over a shorter one.
+//
+// Lookup only reads the table, so any number of goroutines may call it at
+// once, provided none is adding entries at the same time.
+type HostTable[TValue any] struct {
+	trie       *SimpleTrie[string, TValue]
+	multiLabel bool
+}
+
+// hostWildcard is a wildcard entry passed during a lookup, found where the
+// label at depth in the path of the hostname starts.
+type hostWildcard[TValue any] struct {
+	node  *simpleNode[string, TValue]
+	depth int
+}
+
+// NewHostTable creates an empty [HostTable] whose wildcards match a single
+// label.
+func NewHostTable[TValue any]() *HostTable[TValue] {
+	trie, _ := NewTrieWithCodec[string, TValue](hostCodec{}) // a codec is provided, so this cannot fail
+	return &HostTable[TValue]{trie: trie}
+}
+
+// NewMultiLabelHostTable creates an empty [HostTable] whose wildcards match one
+// or more labels, so that *.example.com also matches v1.api.example.com.
+func NewMultiLabelHostTable[TValue any]() *HostTable[TValue] {
+	table := NewHostTable[TValue]()
+	table.multiLabel = true
+	return table
+}
+
+// Add inserts a new hostname or wildcard pattern with its value, overwriting
+// any extant value if the pattern is already present.
+//
+// Parameters:
+//   - pattern is the hostname (api.example.com) or wildcard pattern
+//     (*.example.com) to associate the new value with. Patterns with empty
+//     labels, or with a * anywhere but as the whole leftmost label, are
+//     ignored.
+//   - value is the new value to be stored alongside the pattern.
+//
+// Returns:
+//   - expanded is `true` if this operation created a new entry or `false` if
+//     it replaced a value or the pattern was invalid.
+func (this *HostTable[TValue]) Add(pattern string, value TValue) (expanded bool) {
+	if !validHostPattern(pattern) {
+		return false
+	}
+
+	return this.trie.Add(pattern, value)
+}
+
+// Find retrieves the value stored for exactly the provided hostname or
+// pattern, without applying any wildcards.
+//
+// Parameters:
+//   - pattern is the hostname or wildcard pattern to look up.
+//
+// Returns:
+//   - value is the value stored alongside the pattern, or the zero value.
+//   - found is `true` if the pattern is present, `false` otherwise.
+func (this *HostTable[TValue]) Find(pattern string) (value TValue, found bool) {
+	if !validHostPattern(pattern) {
+		return value, false
+	}
+
+	return this.trie.Find(pattern)
+}
+
+// Lookup finds the most specific entry matching a hostname.
+//
+// Parameters:
+//   - hostname is the hostname to look up.
+//
+// Returns:
+//   - pattern is the matching entry, lowercase and without a trailing dot,
+//     such as api.example.com or *.example.com.
+//   - value is the value stored alongside pattern, or the zero value.
+//   - found is `true` if any entry matches the hostname, `false` otherwise.
+func (this *HostTable[TValue]) Lookup(hostname string) (pattern string, value TValue, found bool) {
+	if !validHostPattern(hostname) || strings.Contains(hostname, "*") {
+		return pattern, value, false
+	}
+
+	path, _ := hostCodec{}.Encode(hostname, make([]uint8, 0, len(hostname)+1)) // hostnames always encode
+	var wildcards []hostWildcard[TValue]
+	node, ok := &this.trie.head, true
+	for i := 0; i < len(path) && ok; i++ {
+		// At the start of each label, a wildcard may stand in for the rest.
+		if i == 0 || path[i-1] == '.' {
+			if star, ok := node.binarySearchNext('*'); ok {
+				if dot, ok := star.binarySearchNext('.'); ok && dot.hasValue {
+					wildcards = append(wildcards, hostWildcard[TValue]{node: dot, depth: i})
+				}
+			}
+		}
+
+		node, ok = node.binarySearchNext(path[i])
+	}
+
+	if ok && node.hasValue {
+		pattern, _ = hostCodec{}.Decode(path)
+		return pattern, node.value, true
+	}
+
+	// Fall back to the longest wildcard able to stand for the labels it replaces.
+	for i := len(wildcards) - 1; i >= 0; i-- {
+		wildcard := wildcards[i]
+		depth := wildcard.depth
+		if depth > 0 && !this.multiLabel && bytes.IndexByte(path[depth:], '.') < len(path)-depth-1 {
+			continue // more than one label remains
+		}
+
+		pattern, _ = hostCodec{}.Decode(append(path[:depth:depth], '*', '.'))
+		return pattern, wildcard.node.value, true
+	}
+
+	return pattern, value, false
+}
+
+// Length returns the number of entries stored.
+func (this *HostTable[TValue]) Length() int {
+	return this.trie.Length()
+}
+
+// All iterates over every entry and its value, grouped by domain from the top
+// level down, with each domain before its subdomains and wildcards.
+func (this *HostTable[TValue]) All() iter.Seq2[string, TValue] {
+	return this.trie.All()
+}
+
+func validHostPattern(pattern string) bool {
+	pattern = strings.TrimSuffix(pattern, ".")
+	if pattern == "" {
+		return false
+	}
+
+	for index, label := range strings.Split(pattern, ".") {
+		if label == "" || (strings.Contains(label, "*") && (index > 0 || label != "*")) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hostCodec encodes a hostname as its lowercase labels in reverse order, each
+// followed by a dot, so that a domain is a prefix of its subdomains and never
+// of a sibling (example.com. does not prefix example.community.).
+type hostCodec struct{}
+
+func (hostCodec) Encode(key string, path []uint8) (encoded []uint8, err error) {
+	key = strings.TrimSuffix(key, ".")
+	for end := len(key); end >= 0; {
+		start := strings.LastIndexByte(key[:end], '.') + 1
+		for i := start; i < end; i++ {
+			unit, _ := ASCIILower(key[i])
+			path = append(path, unit)
+		}
+
+		path = append(path, '.')
+		end = start - 1
+	}
+
+	return path, nil
+}
+
+func (hostCodec) Decode(path []uint8) (key string, err error) {
+	labels := strings.Split(strings.TrimSuffix(string(path), "."), ".")
+	for i, j := 0, len(labels)-1; i < j; i, j = i+1, j-1 {
+		labels[i], labels[j] = labels[j], labels[i]
+	}
+
+	return strings.Join(labels, "."), nil
+}
@@ -0,0 +1,152 @@
+package tries
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func Test_HostTable_Lookup(t *testing.T) {
+	table := NewHostTable[string]()
+	table.Add("example.com", "apex")
+	table.Add("*.example.com", "wildcard")
+	table.Add("*.API.example.com", "api-wildcard")
+	table.Add("v1.api.example.com.", "v1")
+	table.Add("*", "catch-all")
+
+	testTable := map[string]struct {
+		Hostname        string
+		ExpectedPattern string
+		ExpectedValue   string
+		ExpectedFound   bool
+	}{
+		"apex":             {Hostname: "example.com", ExpectedPattern: "example.com", ExpectedValue: "apex", ExpectedFound: true},
+		"case-folded":      {Hostname: "EXAMPLE.com.", ExpectedPattern: "example.com", ExpectedValue: "apex", ExpectedFound: true},
+		"one-label":        {Hostname: "www.example.com", ExpectedPattern: "*.example.com", ExpectedValue: "wildcard", ExpectedFound: true},
+		"many-labels":      {Hostname: "a.b.example.com", ExpectedPattern: "*", ExpectedValue: "catch-all", ExpectedFound: true},
+		"parent-of-wild":   {Hostname: "api.example.com", ExpectedPattern: "*.example.com", ExpectedValue: "wildcard", ExpectedFound: true},
+		"deeper-wildcard":  {Hostname: "v2.api.example.com", ExpectedPattern: "*.api.example.com", ExpectedValue: "api-wildcard", ExpectedFound: true},
+		"exact-beats-wild": {Hostname: "V1.api.example.com", ExpectedPattern: "v1.api.example.com", ExpectedValue: "v1", ExpectedFound: true},
+		"below-exact":      {Hostname: "x.v1.api.example.com", ExpectedPattern: "*", ExpectedValue: "catch-all", ExpectedFound: true},
+		"sibling-domain":   {Hostname: "example.community", ExpectedPattern: "*", ExpectedValue: "catch-all", ExpectedFound: true},
+		"suffix-not-label": {Hostname: "badexample.com", ExpectedPattern: "*", ExpectedValue: "catch-all", ExpectedFound: true},
+		"invalid-empty":    {Hostname: "", ExpectedFound: false},
+		"invalid-label":    {Hostname: "a..example.com", ExpectedFound: false},
+		"invalid-wildcard": {Hostname: "*.example.com", ExpectedFound: false},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			pattern, value, found := table.Lookup(testCase.Hostname)
+			assertions.New(t).So(pattern, should.Equal, testCase.ExpectedPattern)
+			assertions.New(t).So(value, should.Equal, testCase.ExpectedValue)
+			assertions.New(t).So(found, should.Equal, testCase.ExpectedFound)
+		})
+	}
+}
+
+func Test_MultiLabelHostTable_Lookup(t *testing.T) {
+	table := NewMultiLabelHostTable[string]()
+	table.Add("example.com", "apex")
+	table.Add("*.example.com", "wildcard")
+	table.Add("*.api.example.com", "api-wildcard")
+	table.Add("v1.api.example.com", "v1")
+
+	testTable := map[string]struct {
+		Hostname        string
+		ExpectedPattern string
+		ExpectedValue   string
+		ExpectedFound   bool
+	}{
+		"apex":            {Hostname: "example.com", ExpectedPattern: "example.com", ExpectedValue: "apex", ExpectedFound: true},
+		"one-label":       {Hostname: "www.example.com", ExpectedPattern: "*.example.com", ExpectedValue: "wildcard", ExpectedFound: true},
+		"many-labels":     {Hostname: "a.b.example.com", ExpectedPattern: "*.example.com", ExpectedValue: "wildcard", ExpectedFound: true},
+		"deeper-wildcard": {Hostname: "a.b.api.example.com", ExpectedPattern: "*.api.example.com", ExpectedValue: "api-wildcard", ExpectedFound: true},
+		"below-exact":     {Hostname: "x.v1.api.example.com", ExpectedPattern: "*.api.example.com", ExpectedValue: "api-wildcard", ExpectedFound: true},
+		"other-domain":    {Hostname: "example.org", ExpectedFound: false},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			pattern, value, found := table.Lookup(testCase.Hostname)
+			assertions.New(t).So(pattern, should.Equal, testCase.ExpectedPattern)
+			assertions.New(t).So(value, should.Equal, testCase.ExpectedValue)
+			assertions.New(t).So(found, should.Equal, testCase.ExpectedFound)
+		})
+	}
+}
+
+func Test_HostTable_WithoutCatchAll(t *testing.T) {
+	table := NewHostTable[int]()
+	table.Add("*.example.com", 1)
+
+	_, _, found := table.Lookup("example.com")
+	assertions.New(t).So(found, should.BeFalse)
+
+	_, _, found = table.Lookup("example.org")
+	assertions.New(t).So(found, should.BeFalse)
+
+	_, _, found = table.Lookup("a.b.example.com")
+	assertions.New(t).So(found, should.BeFalse)
+}
+
+func Test_HostTable_AddFind(t *testing.T) {
+	table := NewHostTable[int]()
+	assert := assertions.New(t)
+
+	assert.So(table.Add("Example.com", 1), should.BeTrue)
+	assert.So(table.Add("example.COM.", 2), should.BeFalse)
+	assert.So(table.Add("*.example.com", 3), should.BeTrue)
+	assert.So(table.Add("a.*.example.com", 4), should.BeFalse)
+	assert.So(table.Add("w*.example.com", 5), should.BeFalse)
+	assert.So(table.Add("", 6), should.BeFalse)
+	assert.So(table.Length(), should.Equal, 2)
+
+	value, found := table.Find("example.com")
+	assert.So(value, should.Equal, 2)
+	assert.So(found, should.BeTrue)
+
+	value, found = table.Find("www.example.com")
+	assert.So(value, should.Equal, 0)
+	assert.So(found, should.BeFalse)
+
+	assert.So(collectEntries(table.All()), should.Equal, []Entry[string, int]{
+		{"example.com", 2}, {"*.example.com", 3},
+	})
+}
+
+func Test_HostTable_Lookup_Concurrent(t *testing.T) {
+	table := NewHostTable[string]()
+	table.Add("example.com", "apex")
+	table.Add("*.example.com", "wildcard")
+	table.Add("*", "catch-all")
+
+	var waiter sync.WaitGroup
+	failures := make(chan string, 64)
+	for worker := range 8 {
+		waiter.Go(func() {
+			for i := range 200 {
+				hostname := "h" + strconv.Itoa(worker*1000+i) + ".example.com"
+				pattern, value, _ := table.Lookup(hostname)
+				if pattern != "*.example.com" || value != "wildcard" {
+					failures <- hostname + " matched " + pattern
+					return
+				}
+
+				if pattern, _, _ = table.Lookup("v1." + hostname); pattern != "*" {
+					failures <- "v1." + hostname + " matched " + pattern
+					return
+				}
+			}
+		})
+	}
+
+	waiter.Wait()
+	close(failures)
+	for failure := range failures {
+		t.Error("unexpected lookup:", failure)
+	}
+}
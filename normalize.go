@@ -0,0 +1,104 @@
+package tries
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+type (
+	// normalizer holds back the runes of a key until a rune that starts a new
+	// normalization segment arrives, and then emits the normalized form of
+	// everything held back. That way a precomposed rune and its decomposed
+	// equivalent (a base rune followed by combining marks) produce the same
+	// path.
+	normalizer struct {
+		form    norm.Form
+		source  [utf8.UTFMax]uint8
+		pending []uint8
+		scratch []uint8
+	}
+
+	diacriticStripper struct {
+		source  [utf8.UTFMax]uint8
+		scratch []uint8
+	}
+)
+
+// NFC returns a [RuneTransformer] that applies Unicode canonical composition.
+func NFC() RuneTransformer {
+	return &normalizer{form: norm.NFC}
+}
+
+// NFD returns a [RuneTransformer] that applies Unicode canonical decomposition.
+func NFD() RuneTransformer {
+	return &normalizer{form: norm.NFD}
+}
+
+// NFKC returns a [RuneTransformer] that applies Unicode compatibility
+// composition, which also folds variants such as ligatures ("ﬁ" to "fi") and
+// full-width forms.
+func NFKC() RuneTransformer {
+	return &normalizer{form: norm.NFKC}
+}
+
+// NFKD returns a [RuneTransformer] that applies Unicode compatibility
+// decomposition.
+func NFKD() RuneTransformer {
+	return &normalizer{form: norm.NFKD}
+}
+
+func (this *normalizer) Reset() {
+	this.pending = this.pending[:0]
+}
+
+func (this *normalizer) Transform(in rune, out []rune) []rune {
+	size := utf8.EncodeRune(this.source[:], in)
+	if len(this.pending) > 0 && this.form.Properties(this.source[:size]).BoundaryBefore() {
+		out = this.Flush(out)
+	}
+
+	this.pending = append(this.pending, this.source[:size]...)
+	return out
+}
+
+func (this *normalizer) Flush(out []rune) []rune {
+	this.scratch = this.form.Append(this.scratch[:0], this.pending...)
+	this.pending = this.pending[:0]
+	return appendRunes(out, this.scratch)
+}
+
+// StripDiacritics returns a [RuneTransformer] that removes diacritical marks,
+// so that "Zürich" and "Zurich" produce the same path. Runes are decomposed
+// and any nonspacing marks are dropped, which handles both precomposed and
+// decomposed input. Letters whose marks are not separable, such as "ø" or
+// "ł", are kept as they are.
+func StripDiacritics() RuneTransformer {
+	return new(diacriticStripper)
+}
+
+func (this *diacriticStripper) Reset() {}
+
+func (this *diacriticStripper) Transform(in rune, out []rune) []rune {
+	if in < utf8.RuneSelf {
+		return append(out, in)
+	}
+
+	size := utf8.EncodeRune(this.source[:], in)
+	this.scratch = norm.NFD.Append(this.scratch[:0], this.source[:size]...)
+	for decomposed := this.scratch; len(decomposed) > 0; {
+		transformed, size := utf8.DecodeRune(decomposed)
+		if !unicode.Is(unicode.Mn, transformed) {
+			out = append(out, transformed)
+		}
+
+		decomposed = decomposed[size:]
+	}
+
+	return out
+}
+
+func (this *diacriticStripper) Flush(out []rune) []rune {
+	return out
+}
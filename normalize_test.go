@@ -0,0 +1,52 @@
+package tries
+
+import (
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func Test_SimpleTrie_Find_Normalized(t *testing.T) {
+	const (
+		precomposed = "Café"
+		decomposed  = "Café"
+	)
+
+	testTable := map[string]struct {
+		Transformers []func() RuneTransformer
+		Stored       string
+		Input        string
+		Expected     string
+		OK           bool
+	}{
+		"nfc-from-decomposed":  {Transformers: []func() RuneTransformer{NFC}, Stored: precomposed, Input: decomposed, Expected: precomposed, OK: true},
+		"nfc-from-precomposed": {Transformers: []func() RuneTransformer{NFC}, Stored: decomposed, Input: precomposed, Expected: precomposed, OK: true},
+		"nfd":                  {Transformers: []func() RuneTransformer{NFD}, Stored: precomposed, Input: decomposed, Expected: decomposed, OK: true},
+		"nfkc-ligature":        {Transformers: []func() RuneTransformer{NFKC}, Stored: "ﬁnd", Input: "find", Expected: "find", OK: true},
+		"nfkd-full-width":      {Transformers: []func() RuneTransformer{NFKD}, Stored: "ＡＢ", Input: "AB", Expected: "AB", OK: true},
+		"mark-order":           {Transformers: []func() RuneTransformer{NFC}, Stored: "ậ", Input: "ậ", Expected: "ậ", OK: true},
+		"without-normalizing":  {Transformers: nil, Stored: precomposed, Input: decomposed, Expected: "", OK: false},
+		"strip-diacritics": {Transformers: []func() RuneTransformer{StripDiacritics, CaseFold},
+			Stored: "Zürich", Input: "ZURICH", Expected: "zurich", OK: true},
+		"strip-decomposed-diacritics": {Transformers: []func() RuneTransformer{StripDiacritics, CaseFold},
+			Stored: "Zürich", Input: "zürich", Expected: "zurich", OK: true},
+		"strip-keeps-inseparable": {Transformers: []func() RuneTransformer{StripDiacritics},
+			Stored: "Søren", Input: "Soren", Expected: "", OK: false},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			and := assertions.New(t)
+			trie, _ := NewTrieWithOptions[string, int](WithRuneTransforms(testCase.Transformers...))
+			trie.Add(testCase.Stored, 1)
+
+			_, ok := trie.Find(testCase.Input)
+			and.So(ok, should.Equal, testCase.OK)
+			if ok {
+				key, _, _ := trie.Select(0)
+				and.So(key, should.Equal, testCase.Expected)
+			}
+		})
+	}
+}
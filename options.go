@@ -0,0 +1,104 @@
+package tries
+
+type (
+	// Option configures optional behavior of a [Trie] created by
+	// [NewTrieWithOptions].
+	Option func(*configuration)
+
+	configuration struct {
+		stages   []func() transformStage
+		counted  bool
+		original bool
+		ranking  any
+		unitBits int
+	}
+)
+
+// WithTransforms applies the provided [TransformFunc] chain to every key
+// before it is stored or looked up, after any transforms from options that
+// precede this one.
+func WithTransforms(transforms ...TransformFunc) Option {
+	return func(config *configuration) {
+		if len(transforms) == 0 {
+			return
+		}
+
+		config.stages = append(config.stages, newKeyholeStage(transforms))
+	}
+}
+
+// WithTransformers hands every byte of a key to transformers created by the
+// provided factories in order, after any transforms from options that precede
+// this one. Transformers keep state, so every consumer of the transforms (the
+// [Trie] itself, each [Walker] and each [Scanner]) creates its own from each
+// factory. Each transformer is reset before every key.
+func WithTransformers(factories ...func() Transformer) Option {
+	return func(config *configuration) {
+		for _, factory := range factories {
+			config.stages = append(config.stages, newTransformerStage(factory))
+		}
+	}
+}
+
+// WithRuneTransforms switches the [Trie] to a rune-aware key mode, in which
+// keys are decoded as UTF-8 and each rune is handed to transformers created by
+// the provided factories in order, after any transforms from options that
+// precede this one. As with [WithTransformers], every consumer of the
+// transforms creates its own transformers. Paths remain UTF-8 encoded, so a
+// rune may still span several units.
+func WithRuneTransforms(factories ...func() RuneTransformer) Option {
+	return func(config *configuration) {
+		for _, factory := range factories {
+			config.stages = append(config.stages, newRuneStage(factory))
+		}
+	}
+}
+
+// WithSubtreeCounts makes every node track how many values live beneath it.
+// This costs a counter update per node on each insert of a new key, but turns
+// [SimpleTrie.CountPrefix], [SimpleTrie.Rank] and [SimpleTrie.Select] from
+// subtree scans into walks proportional to the depth of the key.
+func WithSubtreeCounts() Option {
+	return func(config *configuration) {
+		config.counted = true
+	}
+}
+
+// WithRanking annotates every node with the highest ranked value beneath it,
+// according to less, so that [SimpleTrie.TopK], when passed a nil less, can
+// skip subtrees that cannot contribute instead of enumerating every
+// descendant. The value type of less must match the value type of the [Trie],
+// or the constructor will fail with [ErrorBadRanking].
+func WithRanking[TValue any](less func(a, b TValue) bool) Option {
+	return func(config *configuration) {
+		config.ranking = less
+	}
+}
+
+// WithOriginalKeys stores each key exactly as it was added alongside its value,
+// at the cost of the memory to hold it. Without it, keys reported by iteration
+// and lookups (such as [SimpleTrie.All] or [SimpleTrie.LongestPrefix]) are
+// reconstructed from their paths, which only preserves their normalized form
+// when transforms are in use. If keys that normalize to the same path are
+// added, the last one added is kept.
+func WithOriginalKeys() Option {
+	return func(config *configuration) {
+		config.original = true
+	}
+}
+
+// WithUnitBits splits every byte of a (transformed) key into path units of the
+// provided number of bits, most significant first, so that the [Trie] branches
+// per bit (1), pair of bits (2) or nibble (4) rather than per byte (8, the
+// default). Any other width fails the constructor with [ErrorBadUnitBits].
+// Narrower units make prefix queries such as [SimpleTrie.CountPrefix]
+// meaningful below byte boundaries, at the cost of more nodes per key. Keys
+// handed to the [Trie] (and units handed to a [Walker]) are still whole bytes;
+// [SimpleTrie.MatchRegexp] and [SimpleTrie.FuzzyFind] operate on path units and
+// so are only meaningful with whole bytes, and [SimpleTrie.Match] and [Scanner]
+// refuse such a [Trie]. To store keys that are not whole bytes, see [Bits].
+func WithUnitBits(bits int) Option {
+	return func(config *configuration) {
+		config.unitBits = bits
+	}
+}
@@ -0,0 +1,147 @@
+package tries
+
+type (
+	// transformStage is one step in turning the raw units of a key into the
+	// units of its trie path. A stage may hold state across the units of a
+	// key, and may emit any number of units for each unit it is handed.
+	transformStage interface {
+		reset()
+		push(unit uint8, out []uint8) []uint8
+		flush(out []uint8) []uint8
+
+		// aligned reports whether the stage emits whole bytes, each while
+		// being pushed the input it came from (or the last byte of the UTF-8
+		// sequence it came from), which lets a [Scanner] map its output back
+		// to offsets in the input.
+		aligned() bool
+
+		// option names the [Option] that added the stage, so that errors
+		// refusing it tell callers which option to drop.
+		option() string
+	}
+
+	// transformPipeline feeds units through a series of stages, where the
+	// output of each stage is the input of the next.
+	transformPipeline struct {
+		stages  []transformStage
+		buffers [][]uint8 // buffers[i] holds the output of stages[i] while it is handed on
+		single  [1]uint8
+	}
+
+	// keyholeStage applies a chain of [TransformFunc], one unit at a time.
+	keyholeStage struct {
+		transforms []TransformFunc
+	}
+
+	// transformerStage hands each unit to a [Transformer].
+	transformerStage struct {
+		transformer Transformer
+	}
+)
+
+func newTransformPipeline(stages []func() transformStage) *transformPipeline {
+	pipeline := &transformPipeline{
+		stages:  make([]transformStage, len(stages)),
+		buffers: make([][]uint8, len(stages)),
+	}
+
+	for i, stage := range stages {
+		pipeline.stages[i] = stage()
+	}
+
+	return pipeline
+}
+
+func (this *transformPipeline) reset() {
+	for _, stage := range this.stages {
+		stage.reset()
+	}
+}
+
+// push hands unit to the first stage, appending whatever comes out of the last
+// stage to out.
+func (this *transformPipeline) push(unit uint8, out []uint8) []uint8 {
+	this.single[0] = unit
+	return this.pushFrom(0, this.single[:], out)
+}
+
+// flush drains every stage in order at the end of a key, appending whatever
+// comes out of the last stage to out.
+func (this *transformPipeline) flush(out []uint8) []uint8 {
+	for i, stage := range this.stages {
+		this.buffers[i] = stage.flush(this.buffers[i][:0])
+		out = this.pushFrom(i+1, this.buffers[i], out)
+	}
+
+	return out
+}
+
+func (this *transformPipeline) pushFrom(index int, units []uint8, out []uint8) []uint8 {
+	if index >= len(this.stages) {
+		return append(out, units...)
+	}
+
+	buffer := this.buffers[index][:0]
+	for _, unit := range units {
+		buffer = this.stages[index].push(unit, buffer)
+	}
+
+	this.buffers[index] = buffer
+	return this.pushFrom(index+1, buffer, out)
+}
+
+func newKeyholeStage(transforms []TransformFunc) func() transformStage {
+	return func() transformStage {
+		return &keyholeStage{transforms: transforms}
+	}
+}
+
+func (this *keyholeStage) reset() {}
+
+func (this *keyholeStage) push(unit uint8, out []uint8) []uint8 {
+	if unit, ok := applyTransforms(this.transforms, unit); ok {
+		return append(out, unit)
+	}
+
+	return out
+}
+
+func (this *keyholeStage) flush(out []uint8) []uint8 {
+	return out
+}
+
+func (this *keyholeStage) aligned() bool {
+	return true
+}
+
+func (this *keyholeStage) option() string {
+	return "WithTransforms"
+}
+
+func newTransformerStage(factory func() Transformer) func() transformStage {
+	return func() transformStage {
+		return &transformerStage{transformer: factory()}
+	}
+}
+
+func (this *transformerStage) reset() {
+	this.transformer.Reset()
+}
+
+func (this *transformerStage) push(unit uint8, out []uint8) []uint8 {
+	return this.transformer.Transform(unit, out)
+}
+
+func (this *transformerStage) flush(out []uint8) []uint8 {
+	return this.transformer.Flush(out)
+}
+
+// aligned is `false` because a [Transformer] may hold units back for as long
+// as it likes.
+func (this *transformerStage) aligned() bool {
+	return false
+}
+
+func (this *transformerStage) option() string {
+	return "WithTransformers"
+}
@@ -0,0 +1,161 @@
+package tries
+
+import (
+	"fmt"
+	"iter"
+	"net/netip"
+)
+
+// PrefixTable maps IP prefixes (such as 10.1.0.0/23) to values and finds the
+// most specific prefix containing an address, as a routing table or firewall
+// does. Prefixes are stored one bit per path unit, so prefix lengths need not
+// fall on byte boundaries. IPv4 and IPv6 prefixes are kept apart, so an IPv4
+// prefix never contains an IPv6 address or vice versa.
+//
+// Lookup only reads the table, so any number of goroutines may call it at
+// once, provided none is adding prefixes at the same time.
+type PrefixTable[TValue any] struct {
+	trie *SimpleTrie[netip.Prefix, TValue]
+}
+
+// NewPrefixTable creates an empty [PrefixTable].
+func NewPrefixTable[TValue any]() *PrefixTable[TValue] {
+	trie, _ := NewTrieWithCodec[netip.Prefix, TValue](prefixCodec{}) // a codec is provided, so this cannot fail
+	return &PrefixTable[TValue]{trie: trie}
+}
+
+// Add inserts a new prefix-value pair, overwriting any extant value if the
+// prefix is already present. Host bits beyond the prefix length are ignored,
+// so 10.1.1.1/23 is stored as 10.1.0.0/23.
+//
+// Parameters:
+//   - prefix is the prefix to associate the new value with. Invalid prefixes
+//     are ignored.
+//   - value is the new value to be stored alongside the prefix.
+//
+// Returns:
+//   - expanded is `true` if this operation created a new entry or `false` if
+//     it replaced a value or the prefix was invalid.
+func (this *PrefixTable[TValue]) Add(prefix netip.Prefix, value TValue) (expanded bool) {
+	if !prefix.IsValid() {
+		return false
+	}
+
+	return this.trie.Add(prefix.Masked(), value)
+}
+
+// Find retrieves the value stored for exactly the provided prefix.
+//
+// Parameters:
+//   - prefix is the prefix to look up. Host bits beyond the prefix length
+//     are ignored.
+//
+// Returns:
+//   - value is the value stored alongside the prefix, or the zero value.
+//   - found is `true` if the prefix is present, `false` otherwise.
+func (this *PrefixTable[TValue]) Find(prefix netip.Prefix) (value TValue, found bool) {
+	if !prefix.IsValid() {
+		return value, false
+	}
+
+	return this.trie.Find(prefix.Masked())
+}
+
+// Lookup finds the longest stored prefix that contains an address. IPv4
+// addresses mapped into IPv6 (::ffff:10.1.1.1) are matched as IPv4, and any
+// IPv6 zone is ignored.
+//
+// Parameters:
+//   - addr is the address to look up.
+//
+// Returns:
+//   - prefix is the longest matching prefix.
+//   - value is the value stored alongside prefix, or the zero value.
+//   - found is `true` if any stored prefix contains the address, `false`
+//     otherwise.
+func (this *PrefixTable[TValue]) Lookup(addr netip.Addr) (prefix netip.Prefix, value TValue, found bool) {
+	if !addr.IsValid() {
+		return prefix, value, false
+	}
+
+	addr = addr.Unmap().WithZone("")
+	// The path holds a version unit and at most 128 bits.
+	var buffer [129]uint8
+	path, _ := prefixCodec{}.Encode(netip.PrefixFrom(addr, addr.BitLen()), buffer[:0]) // valid prefixes always encode
+	var match *simpleNode[netip.Prefix, TValue]
+	matched := 0
+	node, ok := &this.trie.head, true
+	for depth := 0; depth < len(path) && ok; depth++ {
+		if node, ok = node.binarySearchNext(path[depth]); ok && node.hasValue {
+			match, matched = node, depth+1
+		}
+	}
+
+	if match == nil {
+		return prefix, value, false
+	}
+
+	prefix, _ = prefixCodec{}.Decode(path[:matched])
+	return prefix, match.value, true
+}
+
+// Length returns the number of prefixes stored.
+func (this *PrefixTable[TValue]) Length() int {
+	return this.trie.Length()
+}
+
+// All iterates over every prefix and its value, IPv4 before IPv6, with each
+// prefix before the more specific prefixes it contains.
+func (this *PrefixTable[TValue]) All() iter.Seq2[netip.Prefix, TValue] {
+	return this.trie.All()
+}
+
+// prefixCodec encodes a prefix as the version of its address (4 or 6)
+// followed by one unit (0 or 1) for each bit of its prefix length.
+type prefixCodec struct{}
+
+func (prefixCodec) Encode(key netip.Prefix, path []uint8) (encoded []uint8, err error) {
+	addr := key.Addr()
+	if addr.Is4() {
+		path = append(path, 4)
+	} else {
+		path = append(path, 6)
+	}
+
+	units := addr.As16()
+	offset := 128 - addr.BitLen()
+	for bit := range key.Bits() {
+		position := offset + bit
+		path = append(path, units[position/8]>>(7-position%8)&1)
+	}
+
+	return path, nil
+}
+
+func (prefixCodec) Decode(path []uint8) (key netip.Prefix, err error) {
+	if len(path) == 0 || (path[0] != 4 && path[0] != 6) {
+		return key, fmt.Errorf("%w: expected an IP version", ErrorBadTrieKey)
+	}
+
+	bits := path[1:]
+	var units [16]uint8
+	for position, bit := range bits {
+		if position >= len(units)*8 {
+			return key, fmt.Errorf("%w: expected at most 128 bits but found %d", ErrorBadTrieKey, len(bits))
+		} else if bit > 1 {
+			return key, fmt.Errorf("%w: expected a bit but found %d", ErrorBadTrieKey, bit)
+		}
+
+		units[position/8] |= bit << (7 - position%8)
+	}
+
+	if path[0] == 4 {
+		if len(bits) > 32 {
+			return key, fmt.Errorf("%w: expected at most 32 bits but found %d", ErrorBadTrieKey, len(bits))
+		}
+
+		return netip.PrefixFrom(netip.AddrFrom4([4]uint8(units[:4])), len(bits)), nil
+	}
+
+	return netip.PrefixFrom(netip.AddrFrom16(units), len(bits)), nil
+}
@@ -0,0 +1,126 @@
+package tries
+
+import (
+	"net/netip"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func Test_PrefixTable_Lookup(t *testing.T) {
+	table := NewPrefixTable[string]()
+	table.Add(netip.MustParsePrefix("0.0.0.0/0"), "default")
+	table.Add(netip.MustParsePrefix("10.0.0.0/8"), "private")
+	table.Add(netip.MustParsePrefix("10.1.1.1/23"), "office")
+	table.Add(netip.MustParsePrefix("10.1.1.7/32"), "printer")
+	table.Add(netip.MustParsePrefix("2001:db8::/32"), "documentation")
+	table.Add(netip.MustParsePrefix("2001:db8:ab00::/40"), "lab")
+
+	testTable := map[string]struct {
+		Addr           string
+		ExpectedPrefix string
+		ExpectedValue  string
+		ExpectedFound  bool
+	}{
+		"default":           {Addr: "192.168.1.1", ExpectedPrefix: "0.0.0.0/0", ExpectedValue: "default", ExpectedFound: true},
+		"private":           {Addr: "10.2.0.1", ExpectedPrefix: "10.0.0.0/8", ExpectedValue: "private", ExpectedFound: true},
+		"office-low":        {Addr: "10.1.0.1", ExpectedPrefix: "10.1.0.0/23", ExpectedValue: "office", ExpectedFound: true},
+		"office-high":       {Addr: "10.1.1.255", ExpectedPrefix: "10.1.0.0/23", ExpectedValue: "office", ExpectedFound: true},
+		"beyond-office":     {Addr: "10.1.2.0", ExpectedPrefix: "10.0.0.0/8", ExpectedValue: "private", ExpectedFound: true},
+		"host":              {Addr: "10.1.1.7", ExpectedPrefix: "10.1.1.7/32", ExpectedValue: "printer", ExpectedFound: true},
+		"mapped":            {Addr: "::ffff:10.1.1.7", ExpectedPrefix: "10.1.1.7/32", ExpectedValue: "printer", ExpectedFound: true},
+		"ipv6":              {Addr: "2001:db8:1::1", ExpectedPrefix: "2001:db8::/32", ExpectedValue: "documentation", ExpectedFound: true},
+		"ipv6-nested":       {Addr: "2001:db8:abcd::1", ExpectedPrefix: "2001:db8:ab00::/40", ExpectedValue: "lab", ExpectedFound: true},
+		"ipv6-zone":         {Addr: "2001:db8:abcd::1%eth0", ExpectedPrefix: "2001:db8:ab00::/40", ExpectedValue: "lab", ExpectedFound: true},
+		"ipv6-not-in-data":  {Addr: "2001:db9::1", ExpectedFound: false},
+		"invalid-not-found": {Addr: "", ExpectedFound: false},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			addr, _ := netip.ParseAddr(testCase.Addr)
+			prefix, value, found := table.Lookup(addr)
+
+			var expectedPrefix netip.Prefix
+			if testCase.ExpectedFound {
+				expectedPrefix = netip.MustParsePrefix(testCase.ExpectedPrefix)
+			}
+
+			assertions.New(t).So(prefix, should.Equal, expectedPrefix)
+			assertions.New(t).So(value, should.Equal, testCase.ExpectedValue)
+			assertions.New(t).So(found, should.Equal, testCase.ExpectedFound)
+		})
+	}
+}
+
+func Test_PrefixTable_AddFind(t *testing.T) {
+	table := NewPrefixTable[int]()
+	assert := assertions.New(t)
+
+	assert.So(table.Add(netip.MustParsePrefix("10.1.0.0/23"), 1), should.BeTrue)
+	assert.So(table.Add(netip.MustParsePrefix("10.1.1.0/23"), 2), should.BeFalse)
+	assert.So(table.Add(netip.MustParsePrefix("::/0"), 3), should.BeTrue)
+	assert.So(table.Add(netip.Prefix{}, 4), should.BeFalse)
+	assert.So(table.Length(), should.Equal, 2)
+
+	value, found := table.Find(netip.MustParsePrefix("10.1.0.0/23"))
+	assert.So(value, should.Equal, 2)
+	assert.So(found, should.BeTrue)
+
+	value, found = table.Find(netip.MustParsePrefix("10.1.0.0/24"))
+	assert.So(value, should.Equal, 0)
+	assert.So(found, should.BeFalse)
+
+	value, found = table.Find(netip.MustParsePrefix("0.0.0.0/0"))
+	assert.So(value, should.Equal, 0)
+	assert.So(found, should.BeFalse)
+}
+
+func Test_PrefixTable_All(t *testing.T) {
+	table := NewPrefixTable[int]()
+	table.Add(netip.MustParsePrefix("2001:db8::/32"), 4)
+	table.Add(netip.MustParsePrefix("10.1.0.0/23"), 3)
+	table.Add(netip.MustParsePrefix("10.0.0.0/8"), 2)
+	table.Add(netip.MustParsePrefix("0.0.0.0/0"), 1)
+
+	assertions.New(t).So(collectEntries(table.All()), should.Equal, []Entry[netip.Prefix, int]{
+		{netip.MustParsePrefix("0.0.0.0/0"), 1},
+		{netip.MustParsePrefix("10.0.0.0/8"), 2},
+		{netip.MustParsePrefix("10.1.0.0/23"), 3},
+		{netip.MustParsePrefix("2001:db8::/32"), 4},
+	})
+}
+
+func Test_PrefixTable_Lookup_Concurrent(t *testing.T) {
+	table := NewPrefixTable[string]()
+	table.Add(netip.MustParsePrefix("10.0.0.0/8"), "private")
+	table.Add(netip.MustParsePrefix("10.1.0.0/16"), "office")
+
+	var waiter sync.WaitGroup
+	failures := make(chan string, 64)
+	for worker := range 8 {
+		waiter.Go(func() {
+			for i := range 200 {
+				addr := netip.MustParseAddr("10." + strconv.Itoa(worker) + "." + strconv.Itoa(i) + ".1")
+				expected := "private"
+				if worker == 1 {
+					expected = "office"
+				}
+
+				if _, value, _ := table.Lookup(addr); value != expected {
+					failures <- addr.String() + " matched " + value
+					return
+				}
+			}
+		})
+	}
+
+	waiter.Wait()
+	close(failures)
+	for failure := range failures {
+		t.Error("unexpected lookup:", failure)
+	}
+}
@@ -0,0 +1,271 @@
+package tries
+
+import (
+	"regexp/syntax"
+	"slices"
+	"unicode/utf8"
+)
+
+type (
+	// regexpAutomaton is a DFA that is built lazily from a compiled regular
+	// expression as the trie is walked. Each DFA state is the set of program
+	// instructions a match may be waiting in; because empty-width assertions
+	// (such as \b or $) depend on the runes around a position, transitions are
+	// keyed by that context as well as by the rune consumed.
+	regexpAutomaton struct {
+		program  *syntax.Prog
+		anchored bool
+		states   map[string]*regexpState
+		start    *regexpState
+	}
+
+	regexpState struct {
+		pcs      []uint32
+		closures map[syntax.EmptyOp]*regexpClosure
+		next     map[regexpInput]*regexpState
+	}
+
+	regexpInput struct {
+		context syntax.EmptyOp
+		unit    rune
+	}
+
+	// regexpClosure is the set of instructions reachable from a state without
+	// consuming input, in a given context.
+	regexpClosure struct {
+		consuming []uint32
+		matched   bool
+	}
+)
+
+func compileRegexp(pattern string) (*regexpAutomaton, error) {
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+
+	program, err := syntax.Compile(parsed.Simplify())
+	if err != nil {
+		return nil, err
+	}
+
+	automaton := &regexpAutomaton{
+		program:  program,
+		anchored: program.StartCond()&syntax.EmptyBeginText != 0,
+		states:   make(map[string]*regexpState),
+	}
+
+	automaton.start = automaton.state([]uint32{uint32(program.Start)})
+	return automaton, nil
+}
+
+// state returns the canonical DFA state for the provided sorted set of
+// instructions.
+func (this *regexpAutomaton) state(pcs []uint32) *regexpState {
+	key := make([]byte, 0, len(pcs)*4)
+	for _, pc := range pcs {
+		key = utf8.AppendRune(key, rune(pc))
+	}
+
+	state, found := this.states[string(key)]
+	if !found {
+		state = &regexpState{
+			pcs:      pcs,
+			closures: make(map[syntax.EmptyOp]*regexpClosure),
+			next:     make(map[regexpInput]*regexpState),
+		}
+		this.states[string(key)] = state
+	}
+
+	return state
+}
+
+// closure follows every non-consuming instruction from state in the provided
+// context.
+func (this *regexpAutomaton) closure(state *regexpState, context syntax.EmptyOp) *regexpClosure {
+	if closure, found := state.closures[context]; found {
+		return closure
+	}
+
+	closure := new(regexpClosure)
+	visited := make(map[uint32]bool)
+	var follow func(pc uint32)
+	follow = func(pc uint32) {
+		if visited[pc] {
+			return
+		}
+
+		visited[pc] = true
+		inst := &this.program.Inst[pc]
+		switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			follow(inst.Out)
+			follow(inst.Arg)
+		case syntax.InstCapture, syntax.InstNop:
+			follow(inst.Out)
+		case syntax.InstEmptyWidth:
+			if syntax.EmptyOp(inst.Arg)&^context == 0 {
+				follow(inst.Out)
+			}
+		case syntax.InstMatch:
+			closure.matched = true
+		case syntax.InstFail:
+		default:
+			closure.consuming = append(closure.consuming, pc)
+		}
+	}
+
+	for _, pc := range state.pcs {
+		follow(pc)
+	}
+
+	state.closures[context] = closure
+	return closure
+}
+
+// step consumes unit, which follows previous, from state. It reports whether
+// the input before unit already contained a match, in which case the returned
+// state is meaningless.
+func (this *regexpAutomaton) step(state *regexpState, previous, unit rune) (next *regexpState, matched bool) {
+	input := regexpInput{context: syntax.EmptyOpContext(previous, unit), unit: unit}
+	closure := this.closure(state, input.context)
+	if closure.matched {
+		return nil, true
+	}
+
+	if next, found := state.next[input]; found {
+		return next, false
+	}
+
+	var pcs []uint32
+	for _, pc := range closure.consuming {
+		inst := &this.program.Inst[pc]
+		if inst.MatchRune(unit) {
+			pcs = append(pcs, inst.Out)
+		}
+	}
+
+	if !this.anchored {
+		pcs = append(pcs, uint32(this.program.Start))
+	}
+
+	slices.Sort(pcs)
+	next = this.state(slices.Compact(pcs))
+	state.next[input] = next
+	return next, false
+}
+
+// dead reports whether no input can ever lead from state to a match.
+func (this *regexpAutomaton) dead(state *regexpState) bool {
+	return this.anchored && len(state.pcs) == 0
+}
+
+// regexpSearch walks a trie in lockstep with a [regexpAutomaton]. Paths are
+// decoded as UTF-8 so that the automaton sees runes, just as the regexp package
+// would; any byte that cannot be decoded is seen as [utf8.RuneError].
+type regexpSearch[TKey any, TValue any] struct {
+	trie      *SimpleTrie[TKey, TValue]
+	automaton *regexpAutomaton
+	path      []uint8
+}
+
+// regexpPosition is the progress of the automaton along a path, including the
+// bytes of a rune that has only been partially walked.
+type regexpPosition struct {
+	state    *regexpState
+	previous rune
+	pending  int // pending is the number of trailing path bytes not yet consumed
+}
+
+func newRegexpSearch[TKey any, TValue any](trie *SimpleTrie[TKey, TValue], pattern string) (*regexpSearch[TKey, TValue], error) {
+	automaton, err := compileRegexp(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return &regexpSearch[TKey, TValue]{trie: trie, automaton: automaton}, nil
+}
+
+// begin creates a search sharing the automaton, which is only ever extended,
+// but with its own path, so that every range over the results (even within
+// another) walks independently.
+func (this *regexpSearch[TKey, TValue]) begin() *regexpSearch[TKey, TValue] {
+	return &regexpSearch[TKey, TValue]{trie: this.trie, automaton: this.automaton}
+}
+
+func (this *regexpSearch[TKey, TValue]) start() regexpPosition {
+	return regexpPosition{state: this.automaton.start, previous: -1}
+}
+
+func (this *regexpSearch[TKey, TValue]) walk(node *simpleNode[TKey, TValue], position regexpPosition, yield func(TKey, TValue) bool) bool {
+	depth := len(this.path)
+	if node.hasValue && this.finish(position) && !this.yield(this.path, node, yield) {
+		return false
+	}
+
+	for i := range node.next {
+		next := &node.next[i]
+		this.path = append(this.path, next.key)
+		nextPosition := position
+		nextPosition.pending++
+		nextPosition, matched := this.advance(nextPosition, false)
+		switch {
+		case matched:
+			if !next.each(this.path, func(path []uint8, matching *simpleNode[TKey, TValue]) bool {
+				return this.yield(path, matching, yield)
+			}) {
+				return false
+			}
+		case !this.automaton.dead(nextPosition.state):
+			if !this.walk(next, nextPosition, yield) {
+				return false
+			}
+		}
+
+		this.path = this.path[:depth]
+	}
+
+	return true
+}
+
+// advance consumes every complete rune among the pending bytes at the end of
+// the path. At the end of a key, any incomplete rune is consumed byte by byte
+// as well.
+func (this *regexpSearch[TKey, TValue]) advance(position regexpPosition, final bool) (regexpPosition, bool) {
+	for position.pending > 0 {
+		pending := this.path[len(this.path)-position.pending:]
+		if !final && !utf8.FullRune(pending) {
+			break
+		}
+
+		unit, size := utf8.DecodeRune(pending)
+		var matched bool
+		if position.state, matched = this.automaton.step(position.state, position.previous, unit); matched {
+			return position, true
+		}
+
+		position.previous = unit
+		position.pending -= size
+	}
+
+	return position, false
+}
+
+// finish reports whether the path, taken as a whole key, matches.
+func (this *regexpSearch[TKey, TValue]) finish(position regexpPosition) bool {
+	position, matched := this.advance(position, true)
+	if matched {
+		return true
+	}
+
+	return this.automaton.closure(position.state, syntax.EmptyOpContext(position.previous, -1)).matched
+}
+
+func (this *regexpSearch[TKey, TValue]) yield(path []uint8, node *simpleNode[TKey, TValue], yield func(TKey, TValue) bool) bool {
+	key, err := this.trie.key(path, node)
+	if err != nil {
+		return true
+	}
+
+	return yield(key, node.value)
+}
@@ -0,0 +1,100 @@
+package tries
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func Test_SimpleTrie_MatchRegexp(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int]()
+	keys := []string{"84101-1234", "84101", "84101-12345", "x84101-1234", "zürich", "zurich", "Zürich AG", "foo bar", "foobar", "", "\xff\xfe", "a\xc3"}
+	for i, key := range keys {
+		trie.Add(key, i)
+	}
+
+	and := assertions.New(t)
+	patterns := []string{
+		`^[0-9]{5}-[0-9]{4}$`,
+		`[0-9]{5}-[0-9]{4}$`,
+		`^[0-9]{5}`,
+		`^z.rich$`,
+		`ü`,
+		`(?i)^zü`,
+		`\bbar`,
+		`o\b`,
+		`^$`,
+		`.*`,
+		`\x{FFFD}`,
+		`a.$`,
+		`^(foo|zu)`,
+		`notindata`,
+	}
+
+	for _, pattern := range patterns {
+		matches, err := trie.MatchRegexp(pattern)
+		and.So(err, should.BeNil)
+
+		var actual []string
+		for key := range matches {
+			actual = append(actual, key)
+		}
+
+		var expected []string
+		compiled := regexp.MustCompile(pattern)
+		cursor := trie.Cursor()
+		for ok := cursor.First(); ok; ok = cursor.Next() {
+			if compiled.MatchString(cursor.Key()) {
+				expected = append(expected, cursor.Key())
+			}
+		}
+
+		and.So(actual, should.Equal, expected)
+	}
+}
+
+func Test_SimpleTrie_MatchRegexp_Values(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int]()
+	trie.Add("84101-1234", 1)
+	trie.Add("84101-12", 2)
+
+	matches, _ := trie.MatchRegexp(`^[0-9]{5}-[0-9]{4}$`)
+	actual := make(map[string]int)
+	for key, value := range matches {
+		actual[key] = value
+	}
+
+	assertions.New(t).So(actual, should.Equal, map[string]int{"84101-1234": 1})
+}
+
+func Test_SimpleTrie_MatchRegexp_BadPattern(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int]()
+	matches, err := trie.MatchRegexp(`[`)
+
+	and := assertions.New(t)
+	and.So(matches, should.BeNil)
+	and.So(err, should.NotBeNil)
+}
+
+func Test_SimpleTrie_MatchRegexp_NestedRanges(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int]()
+	for i, key := range []string{"ab", "abc", "b", "bcd"} {
+		trie.Add(key, i)
+	}
+
+	matches, _ := trie.MatchRegexp("b")
+	var outer, inner []string
+	for key := range matches {
+		outer = append(outer, key)
+		for key := range matches {
+			inner = append(inner, key)
+		}
+	}
+
+	expected := []string{"ab", "abc", "b", "bcd"}
+	assertions.New(t).So(outer, should.Equal, expected)
+	assertions.New(t).So(inner, should.Equal, slices.Concat(expected, expected, expected, expected))
+}
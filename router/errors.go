@@ -0,0 +1,8 @@
+package router
+
+import "errors"
+
+var (
+	ErrorBadPattern       = errors.New("unable to register route with bad pattern")
+	ErrorConflictingRoute = errors.New("unable to register route that conflicts with another")
+)
@@ -0,0 +1,259 @@
+// Package router dispatches HTTP requests to handlers by method and path,
+// using a trie of path segments.
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/smarty/tries"
+)
+
+// Router is an [http.Handler] that dispatches requests by method and path.
+// It is safe to serve concurrent requests, but routes must all be registered
+// before the first request is served.
+// Patterns are split into segments on "/", and each segment is either
+// static, a parameter (":name") that captures exactly one non-empty
+// segment, or a catch-all ("*name") that captures the rest of the path and
+// must come last. Captures are made available through
+// [http.Request.PathValue].
+//
+// Where several routes match a path, static segments take precedence over
+// parameters, which take precedence over catch-alls, segment by segment from
+// the left; if a more specific branch matches no route further on, the next
+// most specific is tried. A path that matches a route but none of its
+// methods is answered with 405 Method Not Allowed and an Allow header, and
+// HEAD requests fall back to GET handlers.
+type Router struct {
+	// NotFound handles requests that match no route. If nil, [http.NotFound]
+	// is used.
+	NotFound http.Handler
+
+	root *routeNode
+}
+
+// routeNode is a node in the trie of path segments. Its static children are
+// found through a [tries.Walker] created for each lookup, which only reads the
+// shared nodes of the trie, so that concurrent requests don't share state.
+type routeNode struct {
+	static   *tries.SimpleTrie[string, *routeNode]
+	param    *routeNode
+	catchAll *routeNode
+	name     string // name is the capture name of a param or catchAll node
+	handlers map[string]http.Handler
+}
+
+// New creates a [Router] with no routes.
+func New() *Router {
+	return &Router{root: newRouteNode("")}
+}
+
+func newRouteNode(name string) *routeNode {
+	static, _ := tries.NewTrieWithOptions[string, *routeNode]() // string keys are always supported
+	return &routeNode{static: static, name: name}
+}
+
+// Handle registers a handler for a method and path pattern.
+//
+// Parameters:
+//   - method is the HTTP method to match, such as http.MethodGet.
+//   - pattern is the path to match, starting with "/", such as
+//     "/users/:id/files/*path".
+//   - handler handles matching requests.
+//
+// Returns:
+//   - err wraps [ErrorBadPattern] if the pattern is malformed, or
+//     [ErrorConflictingRoute] if the method and pattern are already
+//     registered or a capture at the same position has a different name.
+func (this *Router) Handle(method, pattern string, handler http.Handler) (err error) {
+	if method == "" || handler == nil {
+		return fmt.Errorf("%w: a method and a handler are required for %q", ErrorBadPattern, pattern)
+	}
+
+	if !strings.HasPrefix(pattern, "/") {
+		return fmt.Errorf("%w: %q does not start with /", ErrorBadPattern, pattern)
+	}
+
+	node := this.root
+	segments := strings.Split(pattern[1:], "/")
+	for index, segment := range segments {
+		if node, err = node.child(segment, index == len(segments)-1); err != nil {
+			return fmt.Errorf("%w in %q", err, pattern)
+		}
+	}
+
+	if _, found := node.handlers[method]; found {
+		return fmt.Errorf("%w: %s %q is already registered", ErrorConflictingRoute, method, pattern)
+	}
+
+	if node.handlers == nil {
+		node.handlers = make(map[string]http.Handler)
+	}
+
+	node.handlers[method] = handler
+	return nil
+}
+
+// HandleFunc registers a handler function for a method and path pattern, as
+// [Router.Handle] does.
+func (this *Router) HandleFunc(method, pattern string, handler func(http.ResponseWriter, *http.Request)) error {
+	if handler == nil {
+		return this.Handle(method, pattern, nil)
+	}
+
+	return this.Handle(method, pattern, http.HandlerFunc(handler))
+}
+
+// child finds or creates the child of this node for a pattern segment.
+func (this *routeNode) child(segment string, last bool) (child *routeNode, err error) {
+	switch {
+	case strings.HasPrefix(segment, ":"):
+		return this.capture(&this.param, segment[1:])
+	case strings.HasPrefix(segment, "*"):
+		if !last {
+			return nil, fmt.Errorf("%w: catch-all %q is not the last segment", ErrorBadPattern, segment)
+		}
+
+		return this.capture(&this.catchAll, segment[1:])
+	}
+
+	if child, found := this.static.Find(segment); found {
+		return child, nil
+	}
+
+	child = newRouteNode("")
+	this.static.Add(segment, child)
+	return child, nil
+}
+
+func (this *routeNode) capture(slot **routeNode, name string) (child *routeNode, err error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: a capture is unnamed", ErrorBadPattern)
+	}
+
+	if *slot == nil {
+		*slot = newRouteNode(name)
+	} else if (*slot).name != name {
+		return nil, fmt.Errorf("%w: capture %q is already named %q", ErrorConflictingRoute, name, (*slot).name)
+	}
+
+	return *slot, nil
+}
+
+// ServeHTTP dispatches the request to the handler of the most specific
+// matching route.
+func (this *Router) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	path := request.URL.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	var captures []capture
+	node, captures := this.root.match(path[1:], captures)
+	if node == nil {
+		this.notFound(response, request)
+		return
+	}
+
+	handler, found := node.handlers[request.Method]
+	if !found && request.Method == http.MethodHead {
+		handler, found = node.handlers[http.MethodGet]
+	}
+
+	if !found {
+		response.Header().Set("Allow", node.allow())
+		http.Error(response, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	for _, capture := range captures {
+		request.SetPathValue(capture.name, capture.value)
+	}
+
+	handler.ServeHTTP(response, request)
+}
+
+func (this *Router) notFound(response http.ResponseWriter, request *http.Request) {
+	if this.NotFound != nil {
+		this.NotFound.ServeHTTP(response, request)
+	} else {
+		http.NotFound(response, request)
+	}
+}
+
+type capture struct {
+	name  string
+	value string
+}
+
+// match finds the most specific node with handlers for the rest of a path,
+// where rest follows the "/" that ends the segment of this node.
+func (this *routeNode) match(rest string, captures []capture) (node *routeNode, matched []capture) {
+	segment, remainder, more := strings.Cut(rest, "/")
+	if child, found := this.staticChild(segment); found {
+		if node, matched = child.matchRemainder(remainder, more, captures); node != nil {
+			return node, matched
+		}
+	}
+
+	if this.param != nil && segment != "" {
+		captured := append(captures, capture{name: this.param.name, value: segment})
+		if node, matched = this.param.matchRemainder(remainder, more, captured); node != nil {
+			return node, matched
+		}
+	}
+
+	if this.catchAll != nil && this.catchAll.handlers != nil {
+		return this.catchAll, append(captures, capture{name: this.catchAll.name, value: rest})
+	}
+
+	return nil, captures
+}
+
+// staticChild finds the static child of this node for a path segment without
+// modifying any shared state.
+func (this *routeNode) staticChild(segment string) (child *routeNode, found bool) {
+	walker := this.static.Walker()
+	for index := 0; index < len(segment); index++ {
+		if !walker.Step(segment[index]) {
+			return nil, false
+		}
+	}
+
+	if !walker.Finish() || !walker.HasValue() {
+		return nil, false
+	}
+
+	return walker.Value(), true
+}
+
+func (this *routeNode) matchRemainder(remainder string, more bool, captures []capture) (node *routeNode, matched []capture) {
+	if more {
+		return this.match(remainder, captures)
+	}
+
+	if this.handlers == nil {
+		return nil, captures
+	}
+
+	return this, captures
+}
+
+// allow lists the methods this node handles, for an Allow header.
+func (this *routeNode) allow() string {
+	methods := make([]string, 0, len(this.handlers)+1)
+	for method := range this.handlers {
+		methods = append(methods, method)
+	}
+
+	if _, found := this.handlers[http.MethodGet]; found {
+		if _, found = this.handlers[http.MethodHead]; !found {
+			methods = append(methods, http.MethodHead)
+		}
+	}
+
+	slices.Sort(methods)
+	return strings.Join(methods, ", ")
+}
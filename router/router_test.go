@@ -0,0 +1,137 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func reply(route string, names ...string) http.HandlerFunc {
+	return func(response http.ResponseWriter, request *http.Request) {
+		body := route
+		for _, name := range names {
+			body += " " + name + "=" + request.PathValue(name)
+		}
+
+		_, _ = response.Write([]byte(body))
+	}
+}
+
+func Test_Router_ServeHTTP(t *testing.T) {
+	router := New()
+	_ = router.Handle(http.MethodGet, "/", reply("root"))
+	_ = router.Handle(http.MethodGet, "/users", reply("users"))
+	_ = router.Handle(http.MethodPost, "/users", reply("create-user"))
+	_ = router.Handle(http.MethodGet, "/users/me", reply("me"))
+	_ = router.Handle(http.MethodGet, "/users/:id", reply("user", "id"))
+	_ = router.Handle(http.MethodGet, "/users/:id/files/*path", reply("file", "id", "path"))
+	_ = router.Handle(http.MethodGet, "/users/me/settings", reply("settings"))
+	_ = router.Handle(http.MethodGet, "/static/*path", reply("static", "path"))
+	_ = router.Handle(http.MethodGet, "/static/favicon.ico", reply("favicon"))
+
+	testTable := map[string]struct {
+		Method         string
+		Path           string
+		ExpectedStatus int
+		ExpectedBody   string
+		ExpectedAllow  string
+	}{
+		"root":               {Method: http.MethodGet, Path: "/", ExpectedStatus: 200, ExpectedBody: "root"},
+		"static":             {Method: http.MethodGet, Path: "/users", ExpectedStatus: 200, ExpectedBody: "users"},
+		"method":             {Method: http.MethodPost, Path: "/users", ExpectedStatus: 200, ExpectedBody: "create-user"},
+		"static-over-param":  {Method: http.MethodGet, Path: "/users/me", ExpectedStatus: 200, ExpectedBody: "me"},
+		"param":              {Method: http.MethodGet, Path: "/users/42", ExpectedStatus: 200, ExpectedBody: "user id=42"},
+		"param-backtracking": {Method: http.MethodGet, Path: "/users/me/files/a/b.txt", ExpectedStatus: 200, ExpectedBody: "file id=me path=a/b.txt"},
+		"catch-all":          {Method: http.MethodGet, Path: "/static/css/site.css", ExpectedStatus: 200, ExpectedBody: "static path=css/site.css"},
+		"catch-all-empty":    {Method: http.MethodGet, Path: "/static/", ExpectedStatus: 200, ExpectedBody: "static path="},
+		"static-over-catch":  {Method: http.MethodGet, Path: "/static/favicon.ico", ExpectedStatus: 200, ExpectedBody: "favicon"},
+		"head-falls-back":    {Method: http.MethodHead, Path: "/users/42", ExpectedStatus: 200, ExpectedBody: "user id=42"},
+		"method-not-allowed": {Method: http.MethodDelete, Path: "/users", ExpectedStatus: 405, ExpectedBody: "Method Not Allowed\n", ExpectedAllow: "GET, HEAD, POST"},
+		"segment-boundary":   {Method: http.MethodGet, Path: "/usersx", ExpectedStatus: 404, ExpectedBody: "404 page not found\n"},
+		"trailing-slash":     {Method: http.MethodGet, Path: "/users/", ExpectedStatus: 404, ExpectedBody: "404 page not found\n"},
+		"catch-all-needs-/":  {Method: http.MethodGet, Path: "/static", ExpectedStatus: 404, ExpectedBody: "404 page not found\n"},
+		"too-deep":           {Method: http.MethodGet, Path: "/users/42/extra", ExpectedStatus: 404, ExpectedBody: "404 page not found\n"},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, httptest.NewRequest(testCase.Method, testCase.Path, nil))
+			assertions.New(t).So(response.Code, should.Equal, testCase.ExpectedStatus)
+			assertions.New(t).So(response.Body.String(), should.Equal, testCase.ExpectedBody)
+			assertions.New(t).So(response.Header().Get("Allow"), should.Equal, testCase.ExpectedAllow)
+		})
+	}
+}
+
+func Test_Router_NotFound(t *testing.T) {
+	router := New()
+	router.NotFound = reply("custom")
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	assertions.New(t).So(response.Body.String(), should.Equal, "custom")
+}
+
+func Test_Router_Handle_Errors(t *testing.T) {
+	router := New()
+	_ = router.Handle(http.MethodGet, "/users/:id", reply("user"))
+
+	testTable := map[string]struct {
+		Method   string
+		Pattern  string
+		Handler  http.Handler
+		Expected error
+	}{
+		"relative":           {Method: http.MethodGet, Pattern: "users", Handler: reply(""), Expected: ErrorBadPattern},
+		"no-method":          {Method: "", Pattern: "/users", Handler: reply(""), Expected: ErrorBadPattern},
+		"no-handler":         {Method: http.MethodGet, Pattern: "/users", Handler: nil, Expected: ErrorBadPattern},
+		"unnamed-param":      {Method: http.MethodGet, Pattern: "/files/:", Handler: reply(""), Expected: ErrorBadPattern},
+		"catch-all-not-last": {Method: http.MethodGet, Pattern: "/files/*path/raw", Handler: reply(""), Expected: ErrorBadPattern},
+		"renamed-param":      {Method: http.MethodGet, Pattern: "/users/:name/files", Handler: reply(""), Expected: ErrorConflictingRoute},
+		"duplicate":          {Method: http.MethodGet, Pattern: "/users/:id", Handler: reply(""), Expected: ErrorConflictingRoute},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			err := router.Handle(testCase.Method, testCase.Pattern, testCase.Handler)
+			assertions.New(t).So(err, should.Wrap, testCase.Expected)
+		})
+	}
+
+	assertions.New(t).So(router.HandleFunc(http.MethodPut, "/users/:id", reply("")), should.BeNil)
+}
+
+func Test_Router_ServeHTTP_Concurrent(t *testing.T) {
+	router := New()
+	_ = router.Handle(http.MethodGet, "/users/me", reply("me"))
+	_ = router.Handle(http.MethodGet, "/users/:id", reply("user", "id"))
+	_ = router.Handle(http.MethodGet, "/static/*path", reply("static", "path"))
+
+	var waiter sync.WaitGroup
+	failures := make(chan string, 64)
+	for worker := range 8 {
+		waiter.Go(func() {
+			for i := range 200 {
+				id := strconv.Itoa(worker*1000 + i)
+				response := httptest.NewRecorder()
+				router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/users/"+id, nil))
+				if body := response.Body.String(); body != "user id="+id {
+					failures <- body
+					return
+				}
+			}
+		})
+	}
+
+	waiter.Wait()
+	close(failures)
+	for failure := range failures {
+		t.Error("unexpected response:", failure)
+	}
+}
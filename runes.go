@@ -0,0 +1,128 @@
+package tries
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+)
+
+type (
+	// runeStage decodes the units of a key as UTF-8 and hands each complete
+	// rune to a [RuneTransformer], encoding whatever comes out as UTF-8 again.
+	// Bytes that are not valid UTF-8 are passed along unchanged.
+	runeStage struct {
+		transformer RuneTransformer
+		pending     [utf8.UTFMax]uint8
+		length      int
+		runes       []rune
+	}
+
+	caseFolder struct {
+		caser  cases.Caser
+		source [utf8.UTFMax]uint8
+		target [4 * utf8.UTFMax]uint8
+	}
+)
+
+func newRuneStage(factory func() RuneTransformer) func() transformStage {
+	return func() transformStage {
+		return &runeStage{transformer: factory()}
+	}
+}
+
+func (this *runeStage) reset() {
+	this.length = 0
+	this.transformer.Reset()
+}
+
+func (this *runeStage) push(unit uint8, out []uint8) []uint8 {
+	this.pending[this.length] = unit
+	this.length++
+	for this.length > 0 && utf8.FullRune(this.pending[:this.length]) {
+		in, size := utf8.DecodeRune(this.pending[:this.length])
+		if in == utf8.RuneError && size == 1 {
+			out = append(out, this.pending[0])
+		} else {
+			this.runes = this.transformer.Transform(in, this.runes[:0])
+			out = this.encode(out)
+		}
+
+		this.length = copy(this.pending[:], this.pending[size:this.length])
+	}
+
+	return out
+}
+
+func (this *runeStage) flush(out []uint8) []uint8 {
+	out = append(out, this.pending[:this.length]...)
+	this.length = 0
+	this.runes = this.transformer.Flush(this.runes[:0])
+	return this.encode(out)
+}
+
+// aligned is `true` only for the stock transformers known to transform each
+// rune as it arrives, since any other [RuneTransformer] may hold runes back.
+func (this *runeStage) aligned() bool {
+	switch this.transformer.(type) {
+	case *caseFolder, *diacriticStripper:
+		return true
+	default:
+		return false
+	}
+}
+
+func (this *runeStage) option() string {
+	return "WithRuneTransforms"
+}
+
+func (this *runeStage) encode(out []uint8) []uint8 {
+	for _, transformed := range this.runes {
+		out = utf8.AppendRune(out, transformed)
+	}
+
+	return out
+}
+
+// CaseFold returns a [RuneTransformer] that applies full Unicode case folding,
+// so that keys differing only by case share a path. Unlike lowercasing a byte
+// at a time, this folds every script ("Ä" and "ä", "Σ", "σ" and "ς") and may
+// expand a rune into several ("ß" and "ẞ" to "ss").
+func CaseFold() RuneTransformer {
+	return &caseFolder{caser: cases.Fold()}
+}
+
+func (this *caseFolder) Reset() {}
+
+func (this *caseFolder) Transform(in rune, out []rune) []rune {
+	if in < utf8.RuneSelf {
+		if 'A' <= in && in <= 'Z' {
+			in += 'a' - 'A'
+		}
+
+		return append(out, in)
+	}
+
+	size := utf8.EncodeRune(this.source[:], in)
+	this.caser.Reset()
+	written, _, err := this.caser.Transform(this.target[:], this.source[:size], true)
+	if err != nil {
+		return append(out, in)
+	}
+
+	return appendRunes(out, this.target[:written])
+}
+
+func (this *caseFolder) Flush(out []rune) []rune {
+	return out
+}
+
+// appendRunes decodes the UTF-8 in encoded, appending each rune to out.
+func appendRunes(out []rune, encoded []uint8) []rune {
+	for len(encoded) > 0 {
+		decoded, size := utf8.DecodeRune(encoded)
+		out = append(out, decoded)
+		encoded = encoded[size:]
+	}
+
+	return out
+}
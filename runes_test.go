@@ -0,0 +1,115 @@
+package tries
+
+import (
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func Test_SimpleTrie_Find_CaseFold(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int](WithRuneTransforms(CaseFold))
+	trie.Add("Straße", 1)
+	trie.Add("Äpfel", 2)
+	trie.Add("ΣΊΣΥΦΟΣ", 3)
+	trie.Add("İstanbul", 4)
+
+	testTable := map[string]struct {
+		Input    string
+		Expected int
+		OK       bool
+	}{
+		"ss":           {Input: "STRASSE", Expected: 1, OK: true},
+		"capital-ss":   {Input: "STRAẞE", Expected: 1, OK: true},
+		"lower":        {Input: "äpfel", Expected: 2, OK: true},
+		"upper":        {Input: "ÄPFEL", Expected: 2, OK: true},
+		"sigma":        {Input: "σίσυφος", Expected: 3, OK: true},
+		"final-sigma":  {Input: "σίσυφοσ", Expected: 3, OK: true},
+		"dotted-i":     {Input: "i̇stanbul", Expected: 4, OK: true},
+		"not-in-data":  {Input: "apfel", Expected: 0, OK: false},
+		"invalid-utf8": {Input: "\xffpfel", Expected: 0, OK: false},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			and := assertions.New(t)
+			actual, ok := trie.Find(testCase.Input)
+			and.So(actual, should.Equal, testCase.Expected)
+			and.So(ok, should.Equal, testCase.OK)
+		})
+	}
+}
+
+func Test_SimpleTrie_RuneTransforms_Chained(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int](
+		WithTransforms(func(in byte) (out byte, use bool) { return in, in != '-' }),
+		WithRuneTransforms(CaseFold, func() RuneTransformer { return new(repeatedRuneDropper) }),
+	)
+	trie.Add("Ääl-Straße", 1)
+
+	and := assertions.New(t)
+	value, found := trie.Find("ÄL-STRASE")
+	and.So(found, should.BeTrue)
+	and.So(value, should.Equal, 1)
+
+	key, _, _ := trie.Select(0)
+	and.So(key, should.Equal, "älstrase")
+}
+
+func Test_Walker_Step_CaseFold(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int](WithRuneTransforms(CaseFold))
+	trie.Add("groß", 1)
+
+	and := assertions.New(t)
+	walker := trie.Walker()
+	for _, unit := range []byte("GROSS") {
+		and.So(walker.Step(unit), should.BeTrue)
+	}
+
+	and.So(walker.Value(), should.Equal, 1)
+
+	walker.Reset()
+	for _, unit := range []byte("GROẞ") {
+		and.So(walker.Step(unit), should.BeTrue)
+	}
+
+	and.So(walker.Value(), should.Equal, 1)
+}
+
+func Test_Scanner_Scan_CaseFold(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int](WithRuneTransforms(CaseFold))
+	trie.Add("straße", 1)
+
+	scanner, _ := NewScanner(trie)
+	var actual []Occurrence[string, int]
+	for occurrence := range scanner.Scan("Die STRASSE und die Straße") {
+		actual = append(actual, occurrence)
+	}
+
+	assertions.New(t).So(actual, should.Equal, []Occurrence[string, int]{
+		{Key: "strasse", Value: 1, Start: 4, End: 11},
+		{Key: "strasse", Value: 1, Start: 20, End: 27},
+	})
+}
+
+// repeatedRuneDropper keeps only the first of any run of identical runes.
+type repeatedRuneDropper struct {
+	previous rune
+}
+
+func (this *repeatedRuneDropper) Reset() {
+	this.previous = -1
+}
+
+func (this *repeatedRuneDropper) Transform(in rune, out []rune) []rune {
+	if in == this.previous {
+		return out
+	}
+
+	this.previous = in
+	return append(out, in)
+}
+
+func (this *repeatedRuneDropper) Flush(out []rune) []rune {
+	return out
+}
@@ -0,0 +1,180 @@
+package tries
+
+import (
+	"fmt"
+	"iter"
+	"unicode/utf8"
+)
+
+// Scanner is an Aho-Corasick automaton compiled from the keys of a string
+// [Trie]. It reports every stored key occurring anywhere in a text in a single
+// linear pass. A Scanner is a snapshot; later changes to the [Trie] it was
+// compiled from are not reflected in it.
+type Scanner[TKey TrieString, TValue any] struct {
+	stages []func() transformStage
+	states []scannerState[TKey, TValue] // states[0] is the root
+}
+
+type scannerState[TKey TrieString, TValue any] struct {
+	units    []uint8 // units are sorted, as in simpleNode.next
+	next     []int32 // next[i] is the state reached on units[i]
+	fail     int32   // fail is the state for the longest proper suffix that is also a path in the trie
+	output   int32   // output is the nearest state along the fail chain that holds a value, or 0
+	depth    int
+	hasValue bool
+	key      TKey
+	value    TValue
+}
+
+// NewScanner compiles the keys of trie into a [Scanner]. Texts are passed
+// through the same transforms as the keys of the trie before being scanned,
+// and offsets are reported in terms of the original text. That requires every
+// transformed unit to be traceable to the input it came from, so tries
+// created with [WithTransformers], [WithUnitBits] or [WithRuneTransforms]
+// (other than with [CaseFold] and [StripDiacritics], which transform each rune
+// as it arrives) are refused with [ErrorUnsupportedTransform].
+// The empty key, if present, is never reported.
+func NewScanner[TKey TrieString, TValue any](trie Trie[TKey, TValue]) (scanner *Scanner[TKey, TValue], err error) {
+	simple, ok := trie.(*SimpleTrie[TKey, TValue])
+	if !ok {
+		return nil, fmt.Errorf("%w: unable to compile %T into a Scanner", ErrorUnsupportedTrie, trie)
+	}
+
+	for _, stage := range newTransformPipeline(simple.stages).stages {
+		if !stage.aligned() {
+			return nil, fmt.Errorf("%w: unable to trace the offsets of units transformed by %s", ErrorUnsupportedTransform, stage.option())
+		}
+	}
+
+	scanner = &Scanner[TKey, TValue]{stages: simple.stages}
+	scanner.compile(simple)
+	return scanner, nil
+}
+
+// compile copies the nodes of trie into states in breadth-first order, which
+// guarantees that the fail link of every state is computed before any of its
+// children need it.
+func (this *Scanner[TKey, TValue]) compile(trie *SimpleTrie[TKey, TValue]) {
+	nodes := []*simpleNode[TKey, TValue]{&trie.head}
+	paths := [][]uint8{nil}
+	this.states = append(this.states, scannerState[TKey, TValue]{})
+	for index := 0; index < len(nodes); index++ {
+		node := nodes[index]
+		for i := range node.next {
+			child := &node.next[i]
+			this.states[index].units = append(this.states[index].units, child.key)
+			this.states[index].next = append(this.states[index].next, int32(len(this.states))) //nolint:gosec // states are bounded by memory long before int32
+
+			state := scannerState[TKey, TValue]{depth: this.states[index].depth + 1}
+			path := append(paths[index][:len(paths[index]):len(paths[index])], child.key)
+			if child.hasValue {
+				state.key, _ = trie.key(path, child)
+				state.hasValue = true
+				state.value = child.value
+			}
+
+			if index > 0 {
+				state.fail = this.transition(this.states[index].fail, child.key)
+			}
+
+			if this.states[state.fail].hasValue {
+				state.output = state.fail
+			} else {
+				state.output = this.states[state.fail].output
+			}
+
+			this.states = append(this.states, state)
+			nodes = append(nodes, child)
+			paths = append(paths, path)
+		}
+	}
+}
+
+// transition follows fail links from state until unit can be consumed.
+func (this *Scanner[TKey, TValue]) transition(state int32, unit uint8) int32 {
+	for {
+		current := &this.states[state]
+		index := lowerBound(current.units, unit)
+		if index < len(current.units) && current.units[index] == unit {
+			return current.next[index]
+		}
+
+		if state == 0 {
+			return 0
+		}
+
+		state = current.fail
+	}
+}
+
+// Scan iterates over every occurrence of a stored key in text, ordered by the
+// position at which the occurrence ends, and then from longest to shortest.
+// Occurrences may overlap.
+func (this *Scanner[TKey, TValue]) Scan(text TKey) iter.Seq[Occurrence[TKey, TValue]] {
+	return func(yield func(Occurrence[TKey, TValue]) bool) {
+		var state int32
+		var units []uint8
+		var offsets []int // offsets[i] is the position in text of the rune behind the i-th transformed unit
+		pipeline := newTransformPipeline(this.stages)
+		start := 0
+		for position := 0; position <= len(text); position++ {
+			if position < len(text) {
+				if utf8.RuneStart(text[position]) {
+					start = position
+				}
+
+				units = pipeline.push(text[position], units[:0])
+			} else {
+				units = pipeline.flush(units[:0])
+			}
+
+			end := min(position+1, len(text))
+			for _, unit := range units {
+				offsets = append(offsets, start)
+				state = this.transition(state, unit)
+				if !this.report(state, offsets, end, yield) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// report yields every key that ends at state, including those found by
+// following output links.
+func (this *Scanner[TKey, TValue]) report(state int32, offsets []int, end int, yield func(Occurrence[TKey, TValue]) bool) bool {
+	match := state
+	if !this.states[match].hasValue {
+		match = this.states[match].output
+	}
+
+	for ; match != 0; match = this.states[match].output {
+		found := &this.states[match]
+		if !yield(Occurrence[TKey, TValue]{
+			Key:   found.key,
+			Value: found.value,
+			Start: offsets[len(offsets)-found.depth],
+			End:   end,
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func lowerBound(units []uint8, unit uint8) int {
+	bottom := 0
+	top := len(units)
+	for bottom < top {
+		index := ((top - bottom) / 2) + bottom
+		if units[index] < unit {
+			bottom = index + 1
+			continue
+		}
+
+		top = index
+	}
+
+	return bottom
+}
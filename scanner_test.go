@@ -0,0 +1,135 @@
+package tries
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func Test_Scanner_Scan(t *testing.T) {
+	trie, _ := NewTrie[string, int]()
+	trie.Add("he", 1)
+	trie.Add("she", 2)
+	trie.Add("his", 3)
+	trie.Add("hers", 4)
+	trie.Add("", 5)
+
+	scanner, err := NewScanner(trie)
+
+	and := assertions.New(t)
+	and.So(err, should.BeNil)
+
+	var actual []Occurrence[string, int]
+	for occurrence := range scanner.Scan("ushers, his") {
+		actual = append(actual, occurrence)
+	}
+
+	and.So(actual, should.Equal, []Occurrence[string, int]{
+		{Key: "she", Value: 2, Start: 1, End: 4},
+		{Key: "he", Value: 1, Start: 2, End: 4},
+		{Key: "hers", Value: 4, Start: 2, End: 6},
+		{Key: "his", Value: 3, Start: 8, End: 11},
+	})
+}
+
+func Test_Scanner_Scan_WithTransform(t *testing.T) {
+	trie, _ := NewTrie[string, string](func(in byte) (out byte, use bool) {
+		if in == '-' {
+			return 0, false
+		}
+
+		if in >= 'A' && in <= 'Z' {
+			return in - 'A' + 'a', true
+		}
+
+		return in, true
+	})
+	trie.Add("Acme", "company")
+	trie.Add("ME", "pronoun")
+
+	scanner, _ := NewScanner(trie)
+
+	var actual []Occurrence[string, string]
+	for occurrence := range scanner.Scan("Call AC-ME now") {
+		actual = append(actual, occurrence)
+	}
+
+	assertions.New(t).So(actual, should.Equal, []Occurrence[string, string]{
+		{Key: "acme", Value: "company", Start: 5, End: 10},
+		{Key: "me", Value: "pronoun", Start: 8, End: 10},
+	})
+}
+
+func Test_Scanner_Scan_AgreesWithSubstrings(t *testing.T) {
+	trie, _ := NewTrie[string, int]()
+	for i, key := range []string{"a", "ab", "bab", "bc", "bca", "c", "caa"} {
+		trie.Add(key, i)
+	}
+
+	scanner, _ := NewScanner(trie)
+	text := "abccab" + strings.Repeat("bca", 3) + "aab"
+
+	actual := 0
+	for occurrence := range scanner.Scan(text) {
+		actual++
+		assertions.New(t).So(text[occurrence.Start:occurrence.End], should.Equal, occurrence.Key)
+	}
+
+	expected := 0
+	for start := range text {
+		for end := start + 1; end <= len(text); end++ {
+			if _, found := trie.Find(text[start:end]); found {
+				expected++
+			}
+		}
+	}
+
+	assertions.New(t).So(actual, should.Equal, expected)
+}
+
+func Test_NewScanner_UnsupportedTrie(t *testing.T) {
+	_, err := NewScanner[string, int](nil)
+	assertions.New(t).So(err, should.Wrap, ErrorUnsupportedTrie)
+}
+
+func Test_NewScanner_UnalignedTransforms(t *testing.T) {
+	testTable := map[string]struct {
+		Options []Option
+		Named   string
+	}{
+		"normalized":    {Options: []Option{WithRuneTransforms(NFC)}, Named: "WithRuneTransforms"},
+		"abbreviations": {Options: []Option{WithTransformers(ExpandAbbreviations(map[string]string{"st": "street"}))}, Named: "WithTransformers"},
+		"unit-bits":     {Options: []Option{WithUnitBits(4)}, Named: "WithUnitBits"},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			trie, _ := NewTrieWithOptions[string, int](testCase.Options...)
+			scanner, err := NewScanner(trie)
+			assertions.New(t).So(scanner, should.BeNil)
+			assertions.New(t).So(err, should.Wrap, ErrorUnsupportedTransform)
+			assertions.New(t).So(err.Error(), should.ContainSubstring, testCase.Named)
+		})
+	}
+}
+
+func Test_Scanner_Scan_WithRuneTransforms(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int](WithRuneTransforms(StripDiacritics, CaseFold))
+	trie.Add("strasse", 1)
+	trie.Add("ab", 2)
+
+	scanner, err := NewScanner(trie)
+	assertions.New(t).So(err, should.BeNil)
+
+	var actual []Occurrence[string, int]
+	for occurrence := range scanner.Scan("xxÅbyy STRAẞE") {
+		actual = append(actual, occurrence)
+	}
+
+	assertions.New(t).So(actual, should.Equal, []Occurrence[string, int]{
+		{Key: "ab", Value: 2, Start: 2, End: 5},
+		{Key: "strasse", Value: 1, Start: 8, End: 16},
+	})
+}
@@ -0,0 +1,35 @@
+package tries
+
+// SegmentCodec is a [KeyCodec] for keys made up of whole segments, such as a
+// file or URL path split on "/". Each segment is stored followed by a
+// terminator, so a key is a prefix of another only if its segments are, and
+// [SimpleTrie.WithPrefix] and [SimpleTrie.LongestPrefix] respect segment
+// boundaries: []string{"api", "user"} is not a prefix of
+// []string{"api", "users"}. Keys are ordered segment by segment.
+//
+// Segments are encoded as [TupleCodec] encodes strings, with 0x00 escaped as
+// 0x00 0xFF and 0x00 0x00 ending each segment. Transforms that leave those
+// bytes untouched, such as [ASCIILower], may be used to normalize segments.
+type SegmentCodec struct{}
+
+func (SegmentCodec) Encode(key []string, path []uint8) (encoded []uint8, err error) {
+	for _, segment := range key {
+		path = appendTupleBytes(path, []uint8(segment))
+	}
+
+	return path, nil
+}
+
+func (SegmentCodec) Decode(path []uint8) (key []string, err error) {
+	key = []string{}
+	for len(path) > 0 {
+		var segment []uint8
+		if segment, path, err = decodeTupleBytes(path); err != nil {
+			return nil, err
+		}
+
+		key = append(key, string(segment))
+	}
+
+	return key, nil
+}
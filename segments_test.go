@@ -0,0 +1,84 @@
+package tries
+
+import (
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func Test_SegmentCodec_Prefix(t *testing.T) {
+	trie, _ := NewTrieWithCodec[[]string, string](SegmentCodec{})
+	trie.Add([]string{"api", "users"}, "users")
+	trie.Add([]string{"api", "users", "42"}, "user")
+	trie.Add([]string{"api", "user"}, "legacy")
+	trie.Add([]string{"api", "user\x00s"}, "escaped")
+	trie.Add([]string{"api"}, "api")
+
+	testTable := map[string]struct {
+		Prefix   []string
+		Expected []Entry[[]string, string]
+	}{
+		"whole-segment": {Prefix: []string{"api", "user"}, Expected: []Entry[[]string, string]{
+			{[]string{"api", "user"}, "legacy"},
+		}},
+		"deeper": {Prefix: []string{"api", "users"}, Expected: []Entry[[]string, string]{
+			{[]string{"api", "users"}, "users"}, {[]string{"api", "users", "42"}, "user"},
+		}},
+		"everything": {Prefix: []string{"api"}, Expected: []Entry[[]string, string]{
+			{[]string{"api"}, "api"},
+			{[]string{"api", "user"}, "legacy"},
+			{[]string{"api", "user\x00s"}, "escaped"},
+			{[]string{"api", "users"}, "users"},
+			{[]string{"api", "users", "42"}, "user"},
+		}},
+		"partial-segment": {Prefix: []string{"ap"}, Expected: nil},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			assertions.New(t).So(collectEntries(trie.WithPrefix(testCase.Prefix)), should.Equal, testCase.Expected)
+		})
+	}
+}
+
+func Test_SegmentCodec_LongestPrefix(t *testing.T) {
+	trie, _ := NewTrieWithCodec[[]string, string](SegmentCodec{}, WithTransforms(ASCIILower))
+	trie.Add([]string{"", "api"}, "read")
+	trie.Add([]string{"", "api", "user"}, "deny")
+
+	testTable := map[string]struct {
+		Key            []string
+		ExpectedPrefix []string
+		ExpectedValue  string
+		ExpectedFound  bool
+	}{
+		"exact":            {Key: []string{"", "api", "user"}, ExpectedPrefix: []string{"", "api", "user"}, ExpectedValue: "deny", ExpectedFound: true},
+		"below":            {Key: []string{"", "API", "User", "7"}, ExpectedPrefix: []string{"", "api", "user"}, ExpectedValue: "deny", ExpectedFound: true},
+		"segment-boundary": {Key: []string{"", "api", "users"}, ExpectedPrefix: []string{"", "api"}, ExpectedValue: "read", ExpectedFound: true},
+		"not-in-data":      {Key: []string{"", "web"}, ExpectedPrefix: nil, ExpectedValue: "", ExpectedFound: false},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			prefix, value, found := trie.LongestPrefix(testCase.Key)
+			assertions.New(t).So(prefix, should.Equal, testCase.ExpectedPrefix)
+			assertions.New(t).So(value, should.Equal, testCase.ExpectedValue)
+			assertions.New(t).So(found, should.Equal, testCase.ExpectedFound)
+		})
+	}
+}
+
+func Test_SegmentCodec_RoundTrip(t *testing.T) {
+	codec := SegmentCodec{}
+	for _, key := range [][]string{{}, {""}, {"", "a", ""}, {"\x00", "\xFF"}} {
+		encoded, err := codec.Encode(key, nil)
+		assertions.New(t).So(err, should.BeNil)
+		decoded, err := codec.Decode(encoded)
+		assertions.New(t).So(err, should.BeNil)
+		assertions.New(t).So(decoded, should.Equal, key)
+	}
+
+	_, err := codec.Decode([]uint8{'a'})
+	assertions.New(t).So(err, should.Wrap, ErrorBadTrieKey)
+}
@@ -1,10 +1,32 @@
 package tries
 
-type simpleNode[TKey TrieKey, TValue any] struct {
-	hasValue bool
-	value    TValue
-	key      uint8
-	next     []simpleNode[TKey, TValue]
+// simpleNode is ordered to minimize padding, since every key unit costs one.
+// The annotations pointer costs a trie without options nothing per node for
+// values of four bytes or more, which used to be padded out by as much, but
+// costs a pointer per node for smaller values (such as bool or struct{}).
+type simpleNode[TKey any, TValue any] struct {
+	next        []simpleNode[TKey, TValue]
+	annotations *simpleAnnotations[TKey, TValue] // annotations is nil unless an option calls for them
+	value       TValue
+	key         uint8
+	hasValue    bool
+}
+
+// simpleAnnotations hold what the options of a [SimpleTrie] track per node,
+// apart from the node itself so that tries without those options don't pay
+// for them.
+type simpleAnnotations[TKey any, TValue any] struct {
+	count    int    // count is the number of values at or beneath this node, when counted
+	best     TValue // best is the highest ranked value at or beneath this node, when ranked
+	original TKey   // original is the key as it was added, when preserved
+}
+
+func (this *simpleNode[TKey, TValue]) annotate() *simpleAnnotations[TKey, TValue] {
+	if this.annotations == nil {
+		this.annotations = new(simpleAnnotations[TKey, TValue])
+	}
+
+	return this.annotations
 }
 
 func (this *simpleNode[TKey, TValue]) Find(key converter[TKey]) (value TValue, ok bool) {
@@ -26,22 +48,147 @@ func (this *simpleNode[TKey, TValue]) Find(key converter[TKey]) (value TValue, o
 	return nextNode.Find(key)
 }
 
-func (this *simpleNode[TKey, TValue]) add(key converter[TKey], value TValue) bool {
+func (this *simpleNode[TKey, TValue]) add(key converter[TKey], value TValue, counted bool, ranking func(a, b TValue) bool) (node *simpleNode[TKey, TValue], expanded bool) {
 	k, ok := key.Next()
 	if !ok {
-		expanded := !this.hasValue
+		node = this
+		expanded = !this.hasValue
 		this.hasValue = true
 		this.value = value
-		return expanded
+	} else {
+		nextNode, found := this.binarySearchNext(k)
+		if !found {
+			nextNode = this.insertNewNode(k)
+		}
+
+		node, expanded = nextNode.add(key, value, counted, ranking)
 	}
 
-	nextNode, found := this.binarySearchNext(k)
-	if found {
-		return nextNode.add(key, value)
+	if expanded && counted {
+		this.annotate().count++
 	}
 
-	nextNode = this.insertNewNode(k)
-	return nextNode.add(key, value)
+	if ranking != nil {
+		this.rerank(ranking)
+	}
+
+	return node, expanded
+}
+
+func (this *simpleNode[TKey, TValue]) rerank(ranking func(a, b TValue) bool) {
+	annotations := this.annotate()
+	ranked := this.hasValue
+	if ranked {
+		annotations.best = this.value
+	}
+
+	for i := range this.next {
+		if best := this.next[i].annotations.best; !ranked || ranking(annotations.best, best) {
+			annotations.best = best
+			ranked = true
+		}
+	}
+}
+
+func (this *simpleNode[TKey, TValue]) descend(key converter[TKey]) (node *simpleNode[TKey, TValue], found bool) {
+	node = this
+	for k, ok := key.Next(); ok; k, ok = key.Next() {
+		node, found = node.binarySearchNext(k)
+		if !found {
+			return nil, false
+		}
+	}
+
+	return node, true
+}
+
+// trace is descend, appending the units of the key to path along the way.
+func (this *simpleNode[TKey, TValue]) trace(key converter[TKey], path []uint8) (node *simpleNode[TKey, TValue], _ []uint8, found bool) {
+	node = this
+	for k, ok := key.Next(); ok; k, ok = key.Next() {
+		node, found = node.binarySearchNext(k)
+		if !found {
+			return nil, path, false
+		}
+
+		path = append(path, k)
+	}
+
+	return node, path, true
+}
+
+func (this *simpleNode[TKey, TValue]) size(counted bool) (size int) {
+	if counted {
+		if this.annotations == nil {
+			return 0
+		}
+
+		return this.annotations.count
+	}
+
+	if this.hasValue {
+		size++
+	}
+
+	for i := range this.next {
+		size += this.next[i].size(false)
+	}
+
+	return size
+}
+
+func (this *simpleNode[TKey, TValue]) rank(key converter[TKey], counted bool) (rank int) {
+	node := this
+	for k, ok := key.Next(); ok; k, ok = key.Next() {
+		if node.hasValue {
+			rank++
+		}
+
+		var nextNode *simpleNode[TKey, TValue]
+		for i := range node.next {
+			if node.next[i].key >= k {
+				if node.next[i].key == k {
+					nextNode = &node.next[i]
+				}
+
+				break
+			}
+
+			rank += node.next[i].size(counted)
+		}
+
+		if nextNode == nil {
+			return rank
+		}
+
+		node = nextNode
+	}
+
+	return rank
+}
+
+func (this *simpleNode[TKey, TValue]) selectIndex(path []uint8, index int, counted bool) ([]uint8, *simpleNode[TKey, TValue]) {
+	node := this
+	for {
+		if node.hasValue {
+			if index == 0 {
+				return path, node
+			}
+
+			index--
+		}
+
+		for i := range node.next {
+			size := node.next[i].size(counted)
+			if index < size {
+				node = &node.next[i]
+				path = append(path, node.key)
+				break
+			}
+
+			index -= size
+		}
+	}
 }
 
 func (this *simpleNode[TKey, TValue]) insertNewNode(key uint8) *simpleNode[TKey, TValue] {
@@ -66,6 +213,22 @@ func (this *simpleNode[TKey, TValue]) insertNewNode(key uint8) *simpleNode[TKey,
 	return &this.next[index]
 }
 
+func (this *simpleNode[TKey, TValue]) lowerBound(key uint8) (index int) {
+	bottom := 0
+	top := len(this.next)
+	for bottom < top {
+		index = ((top - bottom) / 2) + bottom
+		if this.next[index].key < key {
+			bottom = index + 1
+			continue
+		}
+
+		top = index
+	}
+
+	return bottom
+}
+
 func (this *simpleNode[TKey, TValue]) binarySearchNext(key uint8) (nextNode *simpleNode[TKey, TValue], found bool) {
 	bottom := 0
 	top := len(this.next) - 1
@@ -87,3 +250,20 @@ func (this *simpleNode[TKey, TValue]) binarySearchNext(key uint8) (nextNode *sim
 
 	return nextNode, false
 }
+
+// each visits every value at or beneath this node in trie order, extending path
+// with the keys of the nodes visited, until visit returns `false`.
+func (this *simpleNode[TKey, TValue]) each(path []uint8, visit func(path []uint8, node *simpleNode[TKey, TValue]) bool) bool {
+	if this.hasValue && !visit(path, this) {
+		return false
+	}
+
+	for i := range this.next {
+		next := &this.next[i]
+		if !next.each(append(path, next.key), visit) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,24 +1,94 @@
 package tries
 
-type SimpleTrie[TKey TrieKey, TValue any] struct {
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// SimpleTrie is the [Trie] implementation, which also provides ordered
+// iteration, prefix queries and searches over its keys. Keys that it reports
+// back, as opposed to those it is handed, are reconstructed from their paths
+// and so are in their normalized form, unless it was created
+// [WithOriginalKeys].
+type SimpleTrie[TKey any, TValue any] struct {
 	converter converter[TKey]
+	stages    []func() transformStage
 	head      simpleNode[TKey, TValue] // head is empty, or the nil key
 	length    int
+	counted   bool
+	original  bool
+	ranking   func(a, b TValue) bool
+	unitBits  int
+	path      []uint8
 }
 
 func NewTrie[TKey TrieKey, TValue any](transforms ...TransformFunc) (trie Trie[TKey, TValue], err error) {
-	var converter converter[TKey]
-	converter, err = selectConverter[TKey]()
+	simple, err := NewTrieWithOptions[TKey, TValue](WithTransforms(transforms...))
+	if err != nil {
+		return nil, err
+	}
+
+	return simple, nil
+}
+
+// NewTrieWithOptions creates a [SimpleTrie] configured by the provided
+// options.
+func NewTrieWithOptions[TKey TrieKey, TValue any](options ...Option) (trie *SimpleTrie[TKey, TValue], err error) {
+	converter, err := selectConverter[TKey]()
 	if err != nil {
 		return nil, err
 	}
 
-	if len(transforms) > 0 {
-		converter = wrapConverter(converter, transforms)
+	return newSimpleTrie[TKey, TValue](converter, options)
+}
+
+// NewTrieWithCodec creates a [SimpleTrie] for a key type without a built-in
+// encoding, such as a struct, by deferring to the codec to convert keys to and
+// from their paths.
+func NewTrieWithCodec[TKey any, TValue any](codec KeyCodec[TKey], options ...Option) (trie *SimpleTrie[TKey, TValue], err error) {
+	if codec == nil {
+		return nil, fmt.Errorf("%w: no codec was provided for type %T", ErrorBadTrieKey, *new(TKey))
+	}
+
+	return newSimpleTrie[TKey, TValue](&converterCodec[TKey]{codec: codec}, options)
+}
+
+func newSimpleTrie[TKey any, TValue any](converter converter[TKey], options []Option) (trie *SimpleTrie[TKey, TValue], err error) {
+	var config configuration
+	for _, option := range options {
+		option(&config)
+	}
+
+	var ranking func(a, b TValue) bool
+	if config.ranking != nil {
+		var ok bool
+		if ranking, ok = config.ranking.(func(a, b TValue) bool); !ok {
+			return nil, fmt.Errorf("%w: expected %T but found %T", ErrorBadRanking, ranking, config.ranking)
+		}
+	}
+
+	stages := config.stages
+	switch config.unitBits {
+	case 0, 8:
+		config.unitBits = 0
+	case 1, 2, 4:
+		stages = append(slices.Clip(stages), newUnitStage(config.unitBits))
+	default:
+		return nil, fmt.Errorf("%w: found %d", ErrorBadUnitBits, config.unitBits)
+	}
+
+	if len(stages) > 0 {
+		converter = wrapConverter(converter, stages)
 	}
 
 	return &SimpleTrie[TKey, TValue]{
 		converter: converter,
+		stages:    stages,
+		counted:   config.counted,
+		original:  config.original,
+		ranking:   ranking,
+		unitBits:  config.unitBits,
 	}, nil
 }
 
@@ -36,8 +106,15 @@ func NewTrieFromMap[TKey TrieIntegerString, TValue any](mapped map[TKey]TValue,
 }
 
 func (this *SimpleTrie[TKey, TValue]) Add(key TKey, value TValue) (expanded bool) {
-	this.converter.Load(key)
-	expanded = this.head.add(this.converter, value)
+	if err := this.converter.Load(key); err != nil {
+		return false
+	}
+
+	node, expanded := this.head.add(this.converter, value, this.counted, this.ranking)
+	if this.original {
+		node.annotate().original = key
+	}
+
 	if expanded {
 		this.length++
 	}
@@ -46,10 +123,331 @@ func (this *SimpleTrie[TKey, TValue]) Add(key TKey, value TValue) (expanded bool
 }
 
 func (this *SimpleTrie[TKey, TValue]) Find(key TKey) (value TValue, found bool) {
-	this.converter.Load(key)
+	if err := this.converter.Load(key); err != nil {
+		return value, false
+	}
+
 	return this.head.Find(this.converter)
 }
 
 func (this *SimpleTrie[TKey, TValue]) Length() (length int) { // LengthMutexed
 	return this.length
 }
+
+// CountPrefix returns the number of keys that start with the provided
+// prefix, including the prefix itself if it is stored as a key.
+//
+// Parameters:
+//   - prefix is the prefix to count beneath.
+//
+// Returns:
+//   - count is the number of matching keys.
+func (this *SimpleTrie[TKey, TValue]) CountPrefix(prefix TKey) (count int) {
+	if err := this.converter.Load(prefix); err != nil {
+		return 0
+	}
+
+	node, found := this.head.descend(this.converter)
+	if !found {
+		return 0
+	}
+
+	return node.size(this.counted)
+}
+
+// Rank returns the number of keys that sort before the provided key in
+// trie order (byte-wise, with a prefix sorting before its extensions).
+// The key does not need to be present.
+//
+// Parameters:
+//   - key is the key to rank.
+//
+// Returns:
+//   - rank is the number of stored keys less than key.
+func (this *SimpleTrie[TKey, TValue]) Rank(key TKey) (rank int) {
+	if err := this.converter.Load(key); err != nil {
+		return 0
+	}
+
+	return this.head.rank(this.converter, this.counted)
+}
+
+// Select returns the key-value pair at the provided zero-based position
+// in trie order, making it the inverse of [SimpleTrie.Rank].
+//
+// Parameters:
+//   - index is the position of the desired entry.
+//
+// Returns:
+//   - key is the key at the position.
+//   - value is the value at the position.
+//   - found is `false` if index is out of range.
+func (this *SimpleTrie[TKey, TValue]) Select(index int) (key TKey, value TValue, found bool) {
+	if index < 0 || index >= this.length {
+		return key, value, false
+	}
+
+	var node *simpleNode[TKey, TValue]
+	this.path, node = this.head.selectIndex(this.path[:0], index, this.counted)
+	key, err := this.key(this.path, node)
+	if err != nil {
+		return key, value, false
+	}
+
+	return key, node.value, true
+}
+
+// All iterates over every entry in trie order.
+//
+// Returns:
+//   - a sequence of every key and its value.
+func (this *SimpleTrie[TKey, TValue]) All() iter.Seq2[TKey, TValue] {
+	return func(yield func(TKey, TValue) bool) {
+		this.head.each(nil, func(path []uint8, node *simpleNode[TKey, TValue]) bool {
+			key, err := this.key(path, node)
+			return err != nil || yield(key, node.value)
+		})
+	}
+}
+
+// WithPrefix iterates, in trie order, over every entry whose key
+// starts with the provided prefix, including the prefix itself if it
+// is stored as a key.
+//
+// Parameters:
+//   - prefix is the prefix to search beneath.
+//
+// Returns:
+//   - a sequence of matching keys and their values.
+func (this *SimpleTrie[TKey, TValue]) WithPrefix(prefix TKey) iter.Seq2[TKey, TValue] {
+	return func(yield func(TKey, TValue) bool) {
+		if err := this.converter.Load(prefix); err != nil {
+			return
+		}
+
+		node, path, found := this.head.trace(this.converter, nil)
+		if !found {
+			return
+		}
+
+		node.each(path, func(path []uint8, node *simpleNode[TKey, TValue]) bool {
+			key, err := this.key(path, node)
+			return err != nil || yield(key, node.value)
+		})
+	}
+}
+
+// LongestPrefix finds the longest stored key that is a prefix of the
+// provided key, including the key itself.
+//
+// Parameters:
+//   - key is the key to look for prefixes of.
+//
+// Returns:
+//   - prefix is the longest matching stored key.
+//   - value is the value stored alongside prefix.
+//   - found is `false` if no stored key is a prefix of key.
+func (this *SimpleTrie[TKey, TValue]) LongestPrefix(key TKey) (prefix TKey, value TValue, found bool) {
+	var err error
+	if this.path, err = loadPath(this.converter, key, this.path[:0]); err != nil {
+		return prefix, value, false
+	}
+
+	node := &this.head
+	var longest *simpleNode[TKey, TValue]
+	depth := 0
+	for i := 0; ; i++ {
+		if node.hasValue {
+			longest = node
+			depth = i
+		}
+
+		if i >= len(this.path) {
+			break
+		}
+
+		if node, found = node.binarySearchNext(this.path[i]); !found {
+			break
+		}
+	}
+
+	if longest == nil {
+		return prefix, value, false
+	}
+
+	prefix, err = this.key(this.path[:depth], longest)
+	if err != nil {
+		return prefix, value, false
+	}
+
+	return prefix, longest.value, true
+}
+
+// Cursor creates a new, unpositioned [Cursor] over this [Trie].
+func (this *SimpleTrie[TKey, TValue]) Cursor() Cursor[TKey, TValue] {
+	return newSimpleCursor(this)
+}
+
+// Walker creates a new [Walker] positioned at the root of this [Trie].
+func (this *SimpleTrie[TKey, TValue]) Walker() Walker[TValue] {
+	return newSimpleWalker(this)
+}
+
+// TopK returns the highest ranked entries among the keys that start
+// with the provided prefix, best first.
+//
+// Parameters:
+//   - prefix is the prefix to search beneath.
+//   - k is the maximum number of entries to return.
+//   - less ranks the values, visiting every entry beneath the prefix. If
+//     less is nil, the ranking provided to [WithRanking] is used instead,
+//     and subtrees are pruned using the annotations it maintains rather
+//     than being enumerated.
+//
+// Returns:
+//   - entries are at most k entries, or none if less is nil and the [Trie]
+//     was created without [WithRanking].
+func (this *SimpleTrie[TKey, TValue]) TopK(prefix TKey, k int, less func(a, b TValue) bool) (entries []Entry[TKey, TValue]) {
+	if k <= 0 || (less == nil && this.ranking == nil) {
+		return nil
+	}
+
+	if err := this.converter.Load(prefix); err != nil {
+		return nil
+	}
+
+	node, path, found := this.head.trace(this.converter, this.path[:0])
+	this.path = path
+	if !found {
+		return nil
+	}
+
+	var ranked []rankedItem[TKey, TValue]
+	if less != nil {
+		ranked = scanTopK(node, path, k, less)
+	} else {
+		ranked = searchTopK(node, path, k, this.ranking)
+	}
+
+	entries = make([]Entry[TKey, TValue], 0, len(ranked))
+	for _, item := range ranked {
+		key, err := this.key(item.path, item.node)
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, Entry[TKey, TValue]{Key: key, Value: item.node.value})
+	}
+
+	return entries
+}
+
+// FuzzyFind iterates, in trie order, over every entry whose key is
+// within the provided Levenshtein distance of key. Distances are
+// measured in units (bytes) after any transforms have been applied.
+//
+// Parameters:
+//   - key is the key to approximate.
+//   - maxEdits is the maximum number of single-unit insertions,
+//     deletions or substitutions allowed.
+//
+// Returns:
+//   - a sequence of matching keys and their values.
+func (this *SimpleTrie[TKey, TValue]) FuzzyFind(key TKey, maxEdits int) iter.Seq2[TKey, TValue] {
+	return this.FuzzyFindCosts(key, maxEdits, UnitCosts{})
+}
+
+// FuzzyFindCosts is [SimpleTrie.FuzzyFind] with a caller-supplied error
+// model, which may price individual edits differently and may allow
+// adjacent transpositions.
+//
+// Parameters:
+//   - key is the key to approximate.
+//   - maxCost is the maximum total cost of the edits allowed.
+//   - costs prices each edit.
+//
+// Returns:
+//   - a sequence of matching keys and their values.
+func (this *SimpleTrie[TKey, TValue]) FuzzyFindCosts(key TKey, maxCost int, costs EditCosts) iter.Seq2[TKey, TValue] {
+	return func(yield func(TKey, TValue) bool) {
+		if maxCost < 0 {
+			return
+		}
+
+		search, err := newFuzzySearch(this, key, maxCost, costs)
+		if err != nil {
+			return
+		}
+
+		search.walk(&this.head, yield)
+	}
+}
+
+// Match iterates, in trie order, over every entry whose key matches a
+// glob pattern. The pattern is matched against the units (bytes) of
+// each key, which makes it most useful for string keys: '?' matches
+// any single byte, '*' matches any run of bytes, '[a-z]' matches one
+// byte from a class ('[!a-z]' or '[^a-z]' negates it) and '\'
+// escapes the next byte. Literal bytes, and each member of a class,
+// pass through the transforms of the [Trie] just as keys do.
+//
+// Parameters:
+//   - pattern is the glob pattern to match.
+//
+// Returns:
+//   - matches is a sequence of matching keys and their values.
+//   - err wraps [ErrorUnsupportedTransform] if the [Trie] transforms keys
+//     with a [Transformer], with a [RuneTransformer] other than
+//     [CaseFold] or [StripDiacritics], or [WithUnitBits], any of which
+//     may transform a unit differently depending on its neighbours.
+func (this *SimpleTrie[TKey, TValue]) Match(pattern string) (iter.Seq2[TKey, TValue], error) {
+	compiled, err := newGlobSearch(this, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(TKey, TValue) bool) {
+		compiled.begin().walk(&this.head, yield)
+	}, nil
+}
+
+// MatchRegexp iterates, in trie order, over every entry whose key
+// matches a regular expression, using the syntax of the regexp
+// package. As with [regexp.Regexp.MatchString], the expression may
+// match anywhere in the key unless it is anchored. The expression is
+// compiled into an automaton that is walked in lockstep with the
+// trie, so subtrees it rejects are never visited. Keys are matched in
+// their transformed form and decoded as UTF-8.
+//
+// Parameters:
+//   - pattern is the regular expression to match.
+//
+// Returns:
+//   - a sequence of matching keys and their values.
+//   - err is set if the pattern cannot be compiled.
+func (this *SimpleTrie[TKey, TValue]) MatchRegexp(pattern string) (iter.Seq2[TKey, TValue], error) {
+	compiled, err := newRegexpSearch(this, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(TKey, TValue) bool) {
+		search := compiled.begin()
+		search.walk(&this.head, search.start(), yield)
+	}, nil
+}
+
+// key reconstructs the key stored at node, which is found at path.
+func (this *SimpleTrie[TKey, TValue]) key(path []uint8, node *simpleNode[TKey, TValue]) (key TKey, err error) {
+	if this.original {
+		return node.annotations.original, nil
+	}
+
+	if this.unitBits > 0 {
+		if path, err = packUnits(path, this.unitBits); err != nil {
+			return key, err
+		}
+	}
+
+	return this.converter.Decode(path)
+}
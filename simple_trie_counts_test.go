@@ -0,0 +1,89 @@
+package tries
+
+import (
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func Test_SimpleTrie_CountPrefix(t *testing.T) {
+	for name, options := range map[string][]Option{
+		"scanned": nil,
+		"counted": {WithSubtreeCounts()},
+	} {
+		trie, _ := NewTrieWithOptions[string, int](options...)
+		trie.Add("Hello", 1)
+		trie.Add("Help", 2)
+		trie.Add("Helicopter", 3)
+		trie.Add("World", 4)
+		trie.Add("", 5)
+		trie.Add("Hello", 6)
+
+		testTable := map[string]struct {
+			Input    string
+			Expected int
+		}{
+			"empty":       {Input: "", Expected: 5},
+			"H":           {Input: "H", Expected: 3},
+			"Hel":         {Input: "Hel", Expected: 3},
+			"Hell":        {Input: "Hell", Expected: 1},
+			"Hello":       {Input: "Hello", Expected: 1},
+			"World":       {Input: "World", Expected: 1},
+			"not-in-data": {Input: "North", Expected: 0},
+		}
+
+		for caseName, testCase := range testTable {
+			t.Run(name+"/"+caseName, func(t *testing.T) {
+				assertions.New(t).So(trie.CountPrefix(testCase.Input), should.Equal, testCase.Expected)
+			})
+		}
+	}
+}
+
+func Test_SimpleTrie_RankSelect(t *testing.T) {
+	for name, options := range map[string][]Option{
+		"scanned": nil,
+		"counted": {WithSubtreeCounts()},
+	} {
+		trie, _ := NewTrieWithOptions[string, int](options...)
+		trie.Add("b", 1)
+		trie.Add("abc", 2)
+		trie.Add("a", 3)
+		trie.Add("ab", 4)
+		trie.Add("c", 5)
+
+		ordered := []string{"a", "ab", "abc", "b", "c"}
+		t.Run(name, func(t *testing.T) {
+			and := assertions.New(t)
+			for index, key := range ordered {
+				and.So(trie.Rank(key), should.Equal, index)
+				actual, _, found := trie.Select(index)
+				and.So(found, should.BeTrue)
+				and.So(actual, should.Equal, key)
+			}
+
+			and.So(trie.Rank(""), should.Equal, 0)
+			and.So(trie.Rank("aa"), should.Equal, 1)
+			and.So(trie.Rank("abd"), should.Equal, 3)
+			and.So(trie.Rank("z"), should.Equal, 5)
+
+			_, _, found := trie.Select(5)
+			and.So(found, should.BeFalse)
+			_, _, found = trie.Select(-1)
+			and.So(found, should.BeFalse)
+		})
+	}
+}
+
+func Test_SimpleTrie_Select_Int64Slice(t *testing.T) {
+	trie, _ := NewTrieWithOptions[[]int64, int](WithSubtreeCounts())
+	trie.Add([]int64{6, 5, 4}, 1)
+	trie.Add([]int64{1, 2, 3}, 2)
+
+	and := assertions.New(t)
+	key, value, found := trie.Select(0)
+	and.So(found, should.BeTrue)
+	and.So(key, should.Equal, []int64{1, 2, 3})
+	and.So(value, should.Equal, 2)
+}
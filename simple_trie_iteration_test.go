@@ -0,0 +1,117 @@
+package tries
+
+import (
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func collectEntries[TKey any, TValue any](entries func(func(TKey, TValue) bool)) (collected []Entry[TKey, TValue]) {
+	for key, value := range entries {
+		collected = append(collected, Entry[TKey, TValue]{Key: key, Value: value})
+	}
+
+	return collected
+}
+
+func Test_SimpleTrie_All(t *testing.T) {
+	trie, _ := NewTrieWithOptions[uint16, string]()
+	trie.Add(0x0102, "b")
+	trie.Add(0x0101, "a")
+	trie.Add(0xFF00, "c")
+
+	assertions.New(t).So(collectEntries(trie.All()), should.Equal, []Entry[uint16, string]{
+		{0x0101, "a"}, {0x0102, "b"}, {0xFF00, "c"},
+	})
+}
+
+func Test_SimpleTrie_WithPrefix(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int]()
+	trie.Add("help", 1)
+	trie.Add("hello", 2)
+	trie.Add("he", 3)
+	trie.Add("world", 4)
+
+	testTable := map[string]struct {
+		Prefix   string
+		Expected []Entry[string, int]
+	}{
+		"everything":  {Prefix: "", Expected: []Entry[string, int]{{"he", 3}, {"hello", 2}, {"help", 1}, {"world", 4}}},
+		"inclusive":   {Prefix: "he", Expected: []Entry[string, int]{{"he", 3}, {"hello", 2}, {"help", 1}}},
+		"inner-node":  {Prefix: "hel", Expected: []Entry[string, int]{{"hello", 2}, {"help", 1}}},
+		"leaf":        {Prefix: "world", Expected: []Entry[string, int]{{"world", 4}}},
+		"not-in-data": {Prefix: "hex", Expected: nil},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			assertions.New(t).So(collectEntries(trie.WithPrefix(testCase.Prefix)), should.Equal, testCase.Expected)
+		})
+	}
+}
+
+func Test_SimpleTrie_LongestPrefix(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int]()
+	trie.Add("10.", 1)
+	trie.Add("10.1.", 2)
+	trie.Add("10.1.2.", 3)
+
+	testTable := map[string]struct {
+		Input    string
+		Expected string
+		Value    int
+		OK       bool
+	}{
+		"exact":       {Input: "10.1.", Expected: "10.1.", Value: 2, OK: true},
+		"longer":      {Input: "10.1.2.3", Expected: "10.1.2.", Value: 3, OK: true},
+		"diverges":    {Input: "10.1.3.4", Expected: "10.1.", Value: 2, OK: true},
+		"shortest":    {Input: "10.9", Expected: "10.", Value: 1, OK: true},
+		"not-in-data": {Input: "192.", Expected: "", Value: 0, OK: false},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			and := assertions.New(t)
+			prefix, value, ok := trie.LongestPrefix(testCase.Input)
+			and.So(prefix, should.Equal, testCase.Expected)
+			and.So(value, should.Equal, testCase.Value)
+			and.So(ok, should.Equal, testCase.OK)
+		})
+	}
+}
+
+func Test_SimpleTrie_OriginalKeys(t *testing.T) {
+	and := assertions.New(t)
+	trie, _ := NewTrieWithOptions[string, int](
+		WithTransforms(ASCIILower, DropPunctuation),
+		WithOriginalKeys(),
+	)
+	trie.Add("McDonald's", 1)
+	trie.Add("Mc-Donald", 2)
+	trie.Add("Burger King", 3)
+	trie.Add("mcdonald", 4) // shares a path with "Mc-Donald", so replaces it
+
+	and.So(collectEntries(trie.All()), should.Equal, []Entry[string, int]{
+		{"Burger King", 3}, {"mcdonald", 4}, {"McDonald's", 1},
+	})
+	and.So(collectEntries(trie.WithPrefix("MCDON")), should.Equal, []Entry[string, int]{
+		{"mcdonald", 4}, {"McDonald's", 1},
+	})
+
+	prefix, value, found := trie.LongestPrefix("mcdonalds restaurant")
+	and.So(prefix, should.Equal, "McDonald's")
+	and.So(value, should.Equal, 1)
+	and.So(found, should.BeTrue)
+
+	key, _, _ := trie.Select(0)
+	and.So(key, should.Equal, "Burger King")
+
+	cursor := trie.Cursor()
+	cursor.Last()
+	and.So(cursor.Key(), should.Equal, "McDonald's")
+
+	for key := range trie.FuzzyFind("mcdonalt", 1) {
+		and.So(key, should.Equal, "mcdonald")
+	}
+}
@@ -1,8 +1,10 @@
 package tries
 
 import (
+	"math"
 	"strings"
 	"testing"
+	"unsafe"
 
 	"github.com/smarty/assertions"
 	"github.com/smarty/assertions/should"
@@ -208,6 +210,134 @@ func Test_SimpleTrie_Find_Int64Slice(t *testing.T) {
 	}
 }
 
+func Test_SimpleTrie_Find_Float64(t *testing.T) {
+	trie, _ := NewTrie[float64, int]()
+	trie.Add(3.25, 1)
+	trie.Add(-3.25, 2)
+	trie.Add(math.Copysign(0, -1), 3)
+	trie.Add(math.Inf(1), 4)
+	trie.Add(math.NaN(), 5)
+
+	testTable := map[string]struct {
+		Input    float64
+		Expected int
+		OK       bool
+	}{
+		"3.25":           {Input: 3.25, Expected: 1, OK: true},
+		"-3.25":          {Input: -3.25, Expected: 2, OK: true},
+		"-0":             {Input: math.Copysign(0, -1), Expected: 3, OK: true},
+		"+Inf":           {Input: math.Inf(1), Expected: 4, OK: true},
+		"NaN":            {Input: math.NaN(), Expected: 5, OK: true},
+		"+0-not-in-data": {Input: 0, Expected: 0, OK: false},
+		"not-in-data":    {Input: 3.5, Expected: 0, OK: false},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			and := assertions.New(t)
+			actual, ok := trie.Find(testCase.Input)
+			and.So(actual, should.Equal, testCase.Expected)
+			and.So(ok, should.Equal, testCase.OK)
+		})
+	}
+}
+
+func Test_SimpleTrie_Order_Float(t *testing.T) {
+	float32s, _ := NewTrieWithOptions[float32, int]()
+	float64s, _ := NewTrieWithOptions[float64, int]()
+	inputs := []float64{1, math.Inf(-1), 0.5, -2, math.Copysign(0, -1), math.Inf(1), -0.5, 0, math.SmallestNonzeroFloat32}
+	for index, input := range inputs {
+		float32s.Add(float32(input), index)
+		float64s.Add(input, index)
+	}
+
+	var keys32 []float32
+	for key := range float32s.All() {
+		keys32 = append(keys32, key)
+	}
+
+	var keys64 []float64
+	for key := range float64s.All() {
+		keys64 = append(keys64, key)
+	}
+
+	expected := []float64{math.Inf(-1), -2, -0.5, math.Copysign(0, -1), 0, math.SmallestNonzeroFloat32, 0.5, 1, math.Inf(1)}
+	assertions.New(t).So(keys64, should.Equal, expected)
+	for index, key := range keys32 {
+		assertions.New(t).So(key, should.Equal, float32(expected[index]))
+	}
+
+	assertions.New(t).So(math.Signbit(keys64[3]), should.BeTrue)
+	assertions.New(t).So(math.Signbit(float64(keys32[3])), should.BeTrue)
+}
+
+func Test_SimpleTrie_Find_Float32Slice(t *testing.T) {
+	trie, _ := NewTrieWithOptions[[]float32, int]()
+	trie.Add([]float32{1.5, -2.5}, 1)
+	trie.Add([]float32{1.5}, 2)
+
+	testTable := map[string]struct {
+		Input    []float32
+		Expected int
+		OK       bool
+	}{
+		"pair":        {Input: []float32{1.5, -2.5}, Expected: 1, OK: true},
+		"single":      {Input: []float32{1.5}, Expected: 2, OK: true},
+		"not-in-data": {Input: []float32{-2.5}, Expected: 0, OK: false},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			and := assertions.New(t)
+			actual, ok := trie.Find(testCase.Input)
+			and.So(actual, should.Equal, testCase.Expected)
+			and.So(ok, should.Equal, testCase.OK)
+		})
+	}
+
+	assertions.New(t).So(collectEntries(trie.All()), should.Equal, []Entry[[]float32, int]{
+		{[]float32{1.5}, 2}, {[]float32{1.5, -2.5}, 1},
+	})
+}
+
+func Test_NewTrie_WithoutTransforms_UsesRawConverter(t *testing.T) {
+	trie, _ := NewTrie[string, int]()
+	_, wrapped := trie.(*SimpleTrie[string, int]).converter.(*converterTransforms[string])
+	assertions.New(t).So(wrapped, should.BeFalse)
+
+	allocations := testing.AllocsPerRun(100, func() { trie.Find("hello") })
+	assertions.New(t).So(allocations, should.Equal, 0)
+}
+
+// baselineNode is the layout of a node from before options kept annotations.
+type baselineNode[TValue any] struct {
+	hasValue bool
+	value    TValue
+	key      uint8
+	next     []baselineNode[TValue]
+}
+
+func Test_SimpleNode_Size(t *testing.T) {
+	const pointer = unsafe.Sizeof(uintptr(0))
+	testTable := map[string]struct {
+		Actual   uintptr
+		Baseline uintptr
+		Extra    uintptr
+	}{
+		"struct": {Actual: unsafe.Sizeof(simpleNode[string, struct{}]{}), Baseline: unsafe.Sizeof(baselineNode[struct{}]{}), Extra: pointer},
+		"bool":   {Actual: unsafe.Sizeof(simpleNode[string, bool]{}), Baseline: unsafe.Sizeof(baselineNode[bool]{}), Extra: pointer},
+		"int32":  {Actual: unsafe.Sizeof(simpleNode[string, int32]{}), Baseline: unsafe.Sizeof(baselineNode[int32]{}), Extra: 0},
+		"int":    {Actual: unsafe.Sizeof(simpleNode[string, int]{}), Baseline: unsafe.Sizeof(baselineNode[int]{}), Extra: 0},
+		"string": {Actual: unsafe.Sizeof(simpleNode[string, string]{}), Baseline: unsafe.Sizeof(baselineNode[string]{}), Extra: 0},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			assertions.New(t).So(testCase.Actual, should.Equal, testCase.Baseline+testCase.Extra)
+		})
+	}
+}
+
 func Test_SimpleTrie_Find_WithTransform(t *testing.T) {
 	trie, _ := NewTrie[string, int](func(in byte) (out byte, use bool) {
 		if in == '-' || in == '_' {
@@ -0,0 +1,126 @@
+package tries
+
+import "slices"
+
+// SuffixTrie indexes every suffix of every key, which allows keys to be found
+// by any substring rather than only by a prefix. It costs memory proportional
+// to the square of the key lengths, so it is best suited to short keys such as
+// identifiers.
+type SuffixTrie[TKey TrieString, TValue any] struct {
+	converter converter[TKey]
+	entries   []Entry[TKey, TValue]
+	index     Trie[TKey, int] // index maps each key to its position in entries
+	suffixes  converter[[]uint8]
+	head      simpleNode[[]uint8, []int] // head holds the positions in entries of the keys a path is a substring of
+	path      []uint8
+}
+
+// NewSuffixTrie creates an empty [SuffixTrie]. Keys and substrings are passed
+// through the provided transforms before being stored or searched for.
+func NewSuffixTrie[TKey TrieString, TValue any](transforms ...TransformFunc) (trie *SuffixTrie[TKey, TValue], err error) {
+	index, err := NewTrie[TKey, int](transforms...)
+	if err != nil {
+		return nil, err
+	}
+
+	var stages []func() transformStage
+	if len(transforms) > 0 {
+		stages = append(stages, newKeyholeStage(transforms))
+	}
+
+	converter, err := newConverter[TKey](stages)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SuffixTrie[TKey, TValue]{
+		converter: converter,
+		index:     index,
+		suffixes:  new(converterInt8Slice[uint8, []uint8]),
+	}, nil
+}
+
+// Add inserts a new key-value pair, overwriting any extant value if the key is
+// already present.
+//
+// Parameters:
+//   - key is the key to associate the new value with.
+//   - value is the new value to be stored alongside the key.
+//
+// Returns:
+//   - expanded is `true` if this operation created a new entry or `false` if
+//     it replaced a value.
+func (this *SuffixTrie[TKey, TValue]) Add(key TKey, value TValue) (expanded bool) {
+	if position, found := this.index.Find(key); found {
+		this.entries[position] = Entry[TKey, TValue]{Key: key, Value: value}
+		return false
+	}
+
+	var err error
+	if this.path, err = loadPath(this.converter, key, this.path[:0]); err != nil {
+		return false
+	}
+
+	position := len(this.entries)
+	this.entries = append(this.entries, Entry[TKey, TValue]{Key: key, Value: value})
+	this.index.Add(key, position)
+
+	for start := 0; start <= len(this.path); start++ {
+		this.suffixes.Load(this.path[start:])
+		node, found := this.head.descend(this.suffixes)
+		if found && node.hasValue {
+			if !slices.Contains(node.value, position) {
+				node.value = append(node.value, position)
+			}
+
+			continue
+		}
+
+		this.suffixes.Load(this.path[start:])
+		this.head.add(this.suffixes, []int{position}, false, nil)
+	}
+
+	return true
+}
+
+// Contains returns every entry whose key contains the provided substring, in
+// the order the keys were first added.
+//
+// Parameters:
+//   - substring is the fragment to search for.
+//
+// Returns:
+//   - entries are the matching entries, with keys as they were added.
+func (this *SuffixTrie[TKey, TValue]) Contains(substring TKey) (entries []Entry[TKey, TValue]) {
+	var err error
+	if this.path, err = loadPath(this.converter, substring, this.path[:0]); err != nil {
+		return nil
+	}
+
+	this.suffixes.Load(this.path)
+	node, found := this.head.descend(this.suffixes)
+	if !found {
+		return nil
+	}
+
+	var positions []int
+	node.each(nil, func(_ []uint8, suffix *simpleNode[[]uint8, []int]) bool {
+		positions = append(positions, suffix.value...)
+		return true
+	})
+
+	slices.Sort(positions)
+	positions = slices.Compact(positions)
+	entries = make([]Entry[TKey, TValue], len(positions))
+	for i, position := range positions {
+		entries[i] = this.entries[position]
+	}
+
+	return entries
+}
+
+// Length returns the current number of key-value pairs stored in this
+// [SuffixTrie].
+func (this *SuffixTrie[TKey, TValue]) Length() (length int) {
+	return len(this.entries)
+}
@@ -0,0 +1,47 @@
+package tries
+
+import (
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func Test_SuffixTrie_Contains(t *testing.T) {
+	trie, _ := NewSuffixTrie[string, int](func(in byte) (out byte, use bool) {
+		return in, in != '-'
+	})
+	trie.Add("AB-4821-X", 1)
+	trie.Add("CD-1482-Y", 2)
+	trie.Add("4821", 3)
+	trie.Add("EF-0000-Z", 4)
+	trie.Add("AB4821X", 5) // replaces the first entry, as the keys only differ by dashes
+
+	testTable := map[string]struct {
+		Input    string
+		Expected []Entry[string, int]
+	}{
+		"middle":      {Input: "4821", Expected: []Entry[string, int]{{"AB4821X", 5}, {"4821", 3}}},
+		"across":      {Input: "B48", Expected: []Entry[string, int]{{"AB4821X", 5}}},
+		"repeated":    {Input: "0", Expected: []Entry[string, int]{{"EF-0000-Z", 4}}},
+		"suffix":      {Input: "2-Y", Expected: []Entry[string, int]{{"CD-1482-Y", 2}}},
+		"whole":       {Input: "CD1482Y", Expected: []Entry[string, int]{{"CD-1482-Y", 2}}},
+		"not-in-data": {Input: "9", Expected: nil},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			actual := trie.Contains(testCase.Input)
+			if testCase.Expected == nil {
+				assertions.New(t).So(actual, should.BeEmpty)
+				return
+			}
+
+			assertions.New(t).So(actual, should.Equal, testCase.Expected)
+		})
+	}
+
+	and := assertions.New(t)
+	and.So(trie.Length(), should.Equal, 4)
+	and.So(trie.Contains(""), should.HaveLength, 4)
+}
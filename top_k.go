@@ -0,0 +1,117 @@
+package tries
+
+import (
+	"container/heap"
+	"slices"
+)
+
+type (
+	rankedItem[TKey any, TValue any] struct {
+		node    *simpleNode[TKey, TValue]
+		path    []uint8
+		subtree bool // subtree is ranked by the best value beneath node rather than its own value
+	}
+
+	// rankedQueue is a heap of ranked items whose root is the lowest ranked
+	// item, or the highest ranked item when descending.
+	rankedQueue[TKey any, TValue any] struct {
+		items      []rankedItem[TKey, TValue]
+		less       func(a, b TValue) bool
+		descending bool
+	}
+)
+
+// searchTopK performs a best-first search using the annotations maintained by
+// ranking, expanding only those subtrees whose best value could still make the
+// cut. Each expansion yields at most one more entry, so the search visits
+// roughly k nodes per level instead of every descendant.
+func searchTopK[TKey any, TValue any](node *simpleNode[TKey, TValue], path []uint8, k int, ranking func(a, b TValue) bool) (ranked []rankedItem[TKey, TValue]) {
+	if !node.hasValue && len(node.next) == 0 {
+		return nil
+	}
+
+	queue := &rankedQueue[TKey, TValue]{less: ranking, descending: true}
+	queue.items = append(queue.items, rankedItem[TKey, TValue]{node: node, path: slices.Clone(path), subtree: true})
+	for queue.Len() > 0 && len(ranked) < k {
+		item := heap.Pop(queue).(rankedItem[TKey, TValue])
+		if !item.subtree {
+			ranked = append(ranked, item)
+			continue
+		}
+
+		if item.node.hasValue {
+			heap.Push(queue, rankedItem[TKey, TValue]{node: item.node, path: item.path})
+		}
+
+		for i := range item.node.next {
+			next := &item.node.next[i]
+			heap.Push(queue, rankedItem[TKey, TValue]{node: next, path: append(slices.Clip(item.path), next.key), subtree: true})
+		}
+	}
+
+	return ranked
+}
+
+// scanTopK visits every value beneath node, keeping the best k seen so far.
+func scanTopK[TKey any, TValue any](node *simpleNode[TKey, TValue], path []uint8, k int, less func(a, b TValue) bool) (ranked []rankedItem[TKey, TValue]) {
+	queue := &rankedQueue[TKey, TValue]{less: less}
+	scanTopKNode(queue, node, slices.Clone(path), k)
+
+	ranked = make([]rankedItem[TKey, TValue], queue.Len())
+	for i := len(ranked) - 1; i >= 0; i-- {
+		ranked[i] = heap.Pop(queue).(rankedItem[TKey, TValue])
+	}
+
+	return ranked
+}
+
+func scanTopKNode[TKey any, TValue any](queue *rankedQueue[TKey, TValue], node *simpleNode[TKey, TValue], path []uint8, k int) {
+	if node.hasValue {
+		switch {
+		case queue.Len() < k:
+			heap.Push(queue, rankedItem[TKey, TValue]{node: node, path: path})
+		case queue.less(queue.items[0].node.value, node.value):
+			queue.items[0] = rankedItem[TKey, TValue]{node: node, path: path}
+			heap.Fix(queue, 0)
+		}
+	}
+
+	for i := range node.next {
+		next := &node.next[i]
+		scanTopKNode(queue, next, append(slices.Clip(path), next.key), k)
+	}
+}
+
+func (this *rankedQueue[TKey, TValue]) Len() int {
+	return len(this.items)
+}
+
+func (this *rankedQueue[TKey, TValue]) Less(i, j int) bool {
+	if this.descending {
+		return this.less(this.items[j].rank(), this.items[i].rank())
+	}
+
+	return this.less(this.items[i].rank(), this.items[j].rank())
+}
+
+func (this *rankedQueue[TKey, TValue]) Swap(i, j int) {
+	this.items[i], this.items[j] = this.items[j], this.items[i]
+}
+
+func (this *rankedQueue[TKey, TValue]) Push(item any) {
+	this.items = append(this.items, item.(rankedItem[TKey, TValue]))
+}
+
+func (this *rankedQueue[TKey, TValue]) Pop() any {
+	item := this.items[len(this.items)-1]
+	this.items = this.items[:len(this.items)-1]
+	return item
+}
+
+func (this rankedItem[TKey, TValue]) rank() TValue {
+	if this.subtree {
+		return this.node.annotations.best
+	}
+
+	return this.node.value
+}
@@ -0,0 +1,94 @@
+package tries
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func Test_SimpleTrie_TopK(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	trie, _ := NewTrieWithOptions[string, int](WithRanking(less))
+	trie.Add("car", 10)
+	trie.Add("cart", 50)
+	trie.Add("carbon", 30)
+	trie.Add("cat", 40)
+	trie.Add("dog", 100)
+	trie.Add("ca", 5)
+	trie.Add("cart", 20) // demoting the best value must demote its ancestors too
+
+	testTable := map[string]struct {
+		Prefix   string
+		K        int
+		Less     func(a, b int) bool
+		Expected []Entry[string, int]
+	}{
+		"ranked": {Prefix: "ca", K: 3, Expected: []Entry[string, int]{{"cat", 40}, {"carbon", 30}, {"cart", 20}}},
+		"scanned": {Prefix: "ca", K: 3, Less: less,
+			Expected: []Entry[string, int]{{"cat", 40}, {"carbon", 30}, {"cart", 20}}},
+		"reversed": {Prefix: "car", K: 2, Less: func(a, b int) bool { return a > b },
+			Expected: []Entry[string, int]{{"car", 10}, {"cart", 20}}},
+		"prefix-is-key":       {Prefix: "car", K: 2, Expected: []Entry[string, int]{{"carbon", 30}, {"cart", 20}}},
+		"more-than-available": {Prefix: "d", K: 5, Expected: []Entry[string, int]{{"dog", 100}}},
+		"not-in-data":         {Prefix: "x", K: 5, Expected: nil},
+		"zero":                {Prefix: "", K: 0, Expected: nil},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			actual := trie.TopK(testCase.Prefix, testCase.K, testCase.Less)
+			if len(testCase.Expected) == 0 {
+				assertions.New(t).So(actual, should.BeEmpty)
+				return
+			}
+
+			assertions.New(t).So(actual, should.Equal, testCase.Expected)
+		})
+	}
+}
+
+func Test_SimpleTrie_TopK_MatchesSortedValues(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	trie, _ := NewTrieWithOptions[string, int](WithRanking(less))
+	random := rand.New(rand.NewSource(42))
+	for i := 0; i < 20000; i++ {
+		trie.Add(fmt.Sprintf("%x", random.Intn(1<<16)), random.Intn(1<<20))
+	}
+
+	and := assertions.New(t)
+	for _, prefix := range []string{"", "a", "1f", "ff"} {
+		var values []int
+		for _, value := range trie.WithPrefix(prefix) {
+			values = append(values, value)
+		}
+		slices.Sort(values)
+		slices.Reverse(values)
+
+		ranked := trie.TopK(prefix, 10, nil)
+		scanned := trie.TopK(prefix, 10, less)
+		and.So(len(ranked), should.Equal, 10)
+		and.So(len(scanned), should.Equal, 10)
+		for i := range ranked {
+			and.So(ranked[i].Value, should.Equal, values[i])
+			and.So(scanned[i].Value, should.Equal, values[i])
+		}
+	}
+}
+
+func Test_SimpleTrie_TopK_WithoutRanking(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int]()
+	trie.Add("car", 10)
+
+	assertions.New(t).So(trie.TopK("c", 1, nil), should.BeEmpty)
+	assertions.New(t).So(trie.TopK("c", 1, func(a, b int) bool { return a < b }), should.Equal,
+		[]Entry[string, int]{{"car", 10}})
+}
+
+func Test_NewTrieWithOptions_BadRanking(t *testing.T) {
+	_, err := NewTrieWithOptions[string, int](WithRanking(func(a, b string) bool { return a < b }))
+	assertions.New(t).So(err, should.Wrap, ErrorBadRanking)
+}
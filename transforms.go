@@ -0,0 +1,183 @@
+package tries
+
+import "unicode"
+
+// ASCIILower is a [TransformFunc] that lowercases ASCII letters, leaving every
+// other byte (including the bytes of multi-byte UTF-8 sequences) untouched.
+// See [CaseFold] for folding beyond ASCII.
+func ASCIILower(in byte) (out byte, use bool) {
+	if 'A' <= in && in <= 'Z' {
+		return in + 'a' - 'A', true
+	}
+
+	return in, true
+}
+
+// ASCIIUpper is a [TransformFunc] that uppercases ASCII letters, leaving every
+// other byte untouched.
+func ASCIIUpper(in byte) (out byte, use bool) {
+	if 'a' <= in && in <= 'z' {
+		return in - 'a' + 'A', true
+	}
+
+	return in, true
+}
+
+// DropPunctuation is a [TransformFunc] that ignores ASCII punctuation and
+// symbols (such as "-", "'", "." or "&").
+func DropPunctuation(in byte) (out byte, use bool) {
+	return in, !isASCIIPunctuation(in)
+}
+
+// DropWhitespace is a [TransformFunc] that ignores ASCII whitespace.
+func DropWhitespace(in byte) (out byte, use bool) {
+	return in, !isASCIIWhitespace(in)
+}
+
+// DigitsOnly is a [TransformFunc] that ignores everything but ASCII digits.
+func DigitsOnly(in byte) (out byte, use bool) {
+	return in, '0' <= in && in <= '9'
+}
+
+// MapSeparators returns a [TransformFunc] that replaces both forward and
+// backward slashes with the provided separator, so that "a\b" and "a/b"
+// produce the same path.
+func MapSeparators(separator byte) TransformFunc {
+	return func(in byte) (out byte, use bool) {
+		if in == '/' || in == '\\' {
+			return separator, true
+		}
+
+		return in, true
+	}
+}
+
+func isASCIIPunctuation(in byte) bool {
+	return ('!' <= in && in <= '/') || (':' <= in && in <= '@') || ('[' <= in && in <= '`') || ('{' <= in && in <= '~')
+}
+
+func isASCIIWhitespace(in byte) bool {
+	return in == ' ' || ('\t' <= in && in <= '\r')
+}
+
+type whitespaceCollapser struct {
+	started bool // started is set once a rune other than whitespace has been seen
+	spaced  bool // spaced is set while a run of whitespace is being held back
+}
+
+// CollapseWhitespace returns a [RuneTransformer] that replaces every run of
+// Unicode whitespace with a single space, and drops leading and trailing
+// whitespace entirely, so that "  Main   St " and "Main St" produce the same
+// path. It must see a whole key to know whether whitespace is trailing, which
+// a [TransformFunc] cannot.
+func CollapseWhitespace() RuneTransformer {
+	return new(whitespaceCollapser)
+}
+
+func (this *whitespaceCollapser) Reset() {
+	this.started = false
+	this.spaced = false
+}
+
+func (this *whitespaceCollapser) Transform(in rune, out []rune) []rune {
+	if unicode.IsSpace(in) {
+		this.spaced = this.started
+		return out
+	}
+
+	if this.spaced {
+		out = append(out, ' ')
+		this.spaced = false
+	}
+
+	this.started = true
+	return append(out, in)
+}
+
+func (this *whitespaceCollapser) Flush(out []rune) []rune {
+	return out
+}
+
+type leadingZeroStripper struct {
+	numbering bool // numbering is set while within a run of digits
+	zeroed    bool // zeroed is set while zeros at the start of a run are being held back
+}
+
+// StripLeadingZeros returns a [Transformer] that removes the leading zeros of
+// every run of ASCII digits in a key, so that "Apt 007" and "Apt 7" produce the
+// same path. A run consisting only of zeros is reduced to a single zero.
+func StripLeadingZeros() Transformer {
+	return new(leadingZeroStripper)
+}
+
+func (this *leadingZeroStripper) Reset() {
+	this.numbering = false
+	this.zeroed = false
+}
+
+func (this *leadingZeroStripper) Transform(in uint8, out []uint8) []uint8 {
+	if in < '0' || in > '9' {
+		out = this.Flush(out)
+		this.numbering = false
+		return append(out, in)
+	}
+
+	if !this.numbering && in == '0' {
+		this.zeroed = true
+		return out
+	}
+
+	this.numbering = true
+	this.zeroed = false
+	return append(out, in)
+}
+
+func (this *leadingZeroStripper) Flush(out []uint8) []uint8 {
+	if this.zeroed {
+		out = append(out, '0')
+		this.zeroed = false
+	}
+
+	return out
+}
+
+type abbreviationExpander struct {
+	expansions map[string]string
+	word       []uint8
+}
+
+// ExpandAbbreviations returns a factory of [Transformer]s, for
+// [WithTransformers], that replace every whole word (a run of ASCII letters
+// and digits) found in expansions with its expansion, so that "Main St" and
+// "Main Street" produce the same path when "St" maps to "Street". Words are
+// matched exactly; place [ASCIILower] before it and use lowercase expansions
+// to match regardless of case.
+func ExpandAbbreviations(expansions map[string]string) func() Transformer {
+	return func() Transformer {
+		return &abbreviationExpander{expansions: expansions}
+	}
+}
+
+func (this *abbreviationExpander) Reset() {
+	this.word = this.word[:0]
+}
+
+func (this *abbreviationExpander) Transform(in uint8, out []uint8) []uint8 {
+	if ('a' <= in && in <= 'z') || ('A' <= in && in <= 'Z') || ('0' <= in && in <= '9') {
+		this.word = append(this.word, in)
+		return out
+	}
+
+	return append(this.Flush(out), in)
+}
+
+func (this *abbreviationExpander) Flush(out []uint8) []uint8 {
+	if expansion, found := this.expansions[string(this.word)]; found {
+		out = append(out, expansion...)
+	} else {
+		out = append(out, this.word...)
+	}
+
+	this.word = this.word[:0]
+	return out
+}
@@ -0,0 +1,46 @@
+package tries
+
+import (
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func Test_StockTransforms(t *testing.T) {
+	testTable := map[string]struct {
+		Options  []Option
+		Input    string
+		Expected string
+	}{
+		"lower":              {Options: []Option{WithTransforms(ASCIILower)}, Input: "Hello, WORLD ä", Expected: "hello, world ä"},
+		"upper":              {Options: []Option{WithTransforms(ASCIIUpper)}, Input: "Hello, world ä", Expected: "HELLO, WORLD ä"},
+		"punctuation":        {Options: []Option{WithTransforms(DropPunctuation)}, Input: "McDonald's, Inc. (#1) ~é", Expected: "McDonalds Inc 1 é"},
+		"whitespace":         {Options: []Option{WithTransforms(DropWhitespace)}, Input: " a\tb\nc\r\n d ", Expected: "abcd"},
+		"digits":             {Options: []Option{WithTransforms(DigitsOnly)}, Input: "(801) 555-0100 x٣", Expected: "8015550100"},
+		"separators":         {Options: []Option{WithTransforms(MapSeparators('/'))}, Input: `C:\Users\me/docs`, Expected: "C:/Users/me/docs"},
+		"collapse":           {Options: []Option{WithRuneTransforms(CollapseWhitespace)}, Input: " \t Main \u00a0  St\n ", Expected: "Main St"},
+		"collapse-only":      {Options: []Option{WithRuneTransforms(CollapseWhitespace)}, Input: "   ", Expected: ""},
+		"leading-zeros":      {Options: []Option{WithTransformers(StripLeadingZeros)}, Input: "Apt 007, Unit 0, 0x10 100", Expected: "Apt 7, Unit 0, 0x10 100"},
+		"trailing-zeros":     {Options: []Option{WithTransformers(StripLeadingZeros)}, Input: "Lot 00", Expected: "Lot 0"},
+		"abbreviations":      {Options: []Option{WithTransforms(ASCIILower), WithTransformers(ExpandAbbreviations(map[string]string{"st": "street", "n": "north"}))}, Input: "100 N Main St.", Expected: "100 north main street."},
+		"abbreviation-parts": {Options: []Option{WithTransformers(ExpandAbbreviations(map[string]string{"St": "Street"}))}, Input: "Stone St", Expected: "Stone Street"},
+		"collapse-and-lower": {Options: []Option{WithTransforms(ASCIILower), WithRuneTransforms(CollapseWhitespace)}, Input: "MAIN    ST", Expected: "main st"},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			and := assertions.New(t)
+			trie, _ := NewTrieWithOptions[string, int](testCase.Options...)
+			trie.Add(testCase.Input, 1)
+
+			key, _, found := trie.Select(0)
+			and.So(found, should.BeTrue)
+			and.So(key, should.Equal, testCase.Expected)
+
+			value, found := trie.Find(testCase.Expected)
+			and.So(found, should.BeTrue)
+			and.So(value, should.Equal, 1)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package tries
+
+import "iter"
+
+// TrieSet stores a set of keys along the same paths as a [Trie], for callers
+// that would otherwise use a Trie with empty values. Its nodes carry no value,
+// only a membership flag, and are kept in a single slice, linked to their
+// first child and next sibling by index, which keeps large word lists
+// compact. Children are scanned in order rather than searched, which suits
+// the small fan-out of most keys but is slower than a [Trie] for dense ones.
+type TrieSet[TKey any] struct {
+	converter converter[TKey]
+	nodes     []setNode // nodes[0] is the root, which is never a child, so index 0 also means none
+	free      int32     // free heads a list of removed nodes, linked through sibling, to reuse
+	length    int
+	path      []uint8
+}
+
+type setNode struct {
+	child   int32 // child is the index of the first child, whose siblings follow in ascending key order
+	sibling int32 // sibling is the index of the next child of the same parent
+	key     uint8
+	member  bool
+}
+
+// NewTrieSet creates an empty [TrieSet]. Keys are passed through the provided
+// transforms before being stored or looked up.
+func NewTrieSet[TKey TrieKey](transforms ...TransformFunc) (set *TrieSet[TKey], err error) {
+	var stages []func() transformStage
+	if len(transforms) > 0 {
+		stages = append(stages, newKeyholeStage(transforms))
+	}
+
+	converter, err := newConverter[TKey](stages)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TrieSet[TKey]{converter: converter, nodes: make([]setNode, 1)}, nil
+}
+
+// Insert adds a key to the set.
+//
+// Parameters:
+//   - key is the key to add.
+//
+// Returns:
+//   - inserted is `true` if the key was not already present.
+func (this *TrieSet[TKey]) Insert(key TKey) (inserted bool) {
+	if err := this.converter.Load(key); err != nil {
+		return false
+	}
+
+	var node int32
+	for unit, ok := this.converter.Next(); ok; unit, ok = this.converter.Next() {
+		child, previous := this.search(node, unit)
+		if child == 0 {
+			child = this.insert(node, previous, unit)
+		}
+
+		node = child
+	}
+
+	if this.nodes[node].member {
+		return false
+	}
+
+	this.nodes[node].member = true
+	this.length++
+	return true
+}
+
+// Contains reports whether a key is in the set.
+func (this *TrieSet[TKey]) Contains(key TKey) bool {
+	var err error
+	if this.path, err = loadPath(this.converter, key, this.path[:0]); err != nil {
+		return false
+	}
+
+	node, found := this.descend(this.path)
+	return found && this.nodes[node].member
+}
+
+// Remove deletes a key from the set, along with any nodes that no longer lead
+// to a key.
+//
+// Parameters:
+//   - key is the key to delete.
+//
+// Returns:
+//   - removed is `true` if the key was present.
+func (this *TrieSet[TKey]) Remove(key TKey) (removed bool) {
+	var err error
+	if this.path, err = loadPath(this.converter, key, this.path[:0]); err != nil {
+		return false
+	}
+
+	return this.remove(0, this.path)
+}
+
+// Length returns the number of keys in the set.
+func (this *TrieSet[TKey]) Length() int {
+	return this.length
+}
+
+// All iterates over every key in the set, in trie order.
+func (this *TrieSet[TKey]) All() iter.Seq[TKey] {
+	return func(yield func(TKey) bool) {
+		this.each(0, nil, yield)
+	}
+}
+
+// WithPrefix iterates over every key in the set that starts with prefix, in
+// trie order.
+func (this *TrieSet[TKey]) WithPrefix(prefix TKey) iter.Seq[TKey] {
+	return func(yield func(TKey) bool) {
+		path, err := loadPath(this.converter, prefix, nil)
+		if err != nil {
+			return
+		}
+
+		if node, found := this.descend(path); found {
+			this.each(node, path, yield)
+		}
+	}
+}
+
+// search finds the child of node with the provided key, or returns 0 and the
+// last child with a lower key (0 if there is none), after which it belongs.
+func (this *TrieSet[TKey]) search(node int32, unit uint8) (child, previous int32) {
+	for child = this.nodes[node].child; child != 0; child = this.nodes[child].sibling {
+		if key := this.nodes[child].key; key == unit {
+			return child, previous
+		} else if key > unit {
+			break
+		}
+
+		previous = child
+	}
+
+	return 0, previous
+}
+
+// insert links a new child with the provided key into node after previous.
+func (this *TrieSet[TKey]) insert(node, previous int32, unit uint8) (child int32) {
+	if this.free != 0 {
+		child = this.free
+		this.free = this.nodes[child].sibling
+		this.nodes[child] = setNode{key: unit}
+	} else {
+		child = int32(len(this.nodes)) //nolint:gosec // nodes are bounded by memory long before int32
+		this.nodes = append(this.nodes, setNode{key: unit})
+	}
+
+	if previous == 0 {
+		this.nodes[child].sibling = this.nodes[node].child
+		this.nodes[node].child = child
+	} else {
+		this.nodes[child].sibling = this.nodes[previous].sibling
+		this.nodes[previous].sibling = child
+	}
+
+	return child
+}
+
+func (this *TrieSet[TKey]) descend(path []uint8) (node int32, found bool) {
+	for _, unit := range path {
+		if node, _ = this.search(node, unit); node == 0 {
+			return 0, false
+		}
+	}
+
+	return node, true
+}
+
+// remove unsets the member at path beneath node, unlinking the nodes that no
+// longer lead to one, and reports whether it was set.
+func (this *TrieSet[TKey]) remove(node int32, path []uint8) (removed bool) {
+	if len(path) == 0 {
+		removed = this.nodes[node].member
+		this.nodes[node].member = false
+		if removed {
+			this.length--
+		}
+
+		return removed
+	}
+
+	child, previous := this.search(node, path[0])
+	if child == 0 || !this.remove(child, path[1:]) {
+		return false
+	}
+
+	if pruned := &this.nodes[child]; !pruned.member && pruned.child == 0 {
+		if previous == 0 {
+			this.nodes[node].child = pruned.sibling
+		} else {
+			this.nodes[previous].sibling = pruned.sibling
+		}
+
+		pruned.sibling = this.free
+		this.free = child
+	}
+
+	return true
+}
+
+// each yields every member at or beneath node in trie order, extending path
+// with the keys of the nodes visited, until yield returns `false`.
+func (this *TrieSet[TKey]) each(node int32, path []uint8, yield func(TKey) bool) bool {
+	if this.nodes[node].member {
+		if key, err := this.converter.Decode(path); err == nil && !yield(key) {
+			return false
+		}
+	}
+
+	for child := this.nodes[node].child; child != 0; child = this.nodes[child].sibling {
+		if !this.each(child, append(path, this.nodes[child].key), yield) {
+			return false
+		}
+	}
+
+	return true
+}
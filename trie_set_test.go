@@ -0,0 +1,86 @@
+package tries
+
+import (
+	"slices"
+	"testing"
+	"unsafe"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func Test_TrieSet(t *testing.T) {
+	set, err := NewTrieSet[string](ASCIILower)
+	assertions.New(t).So(err, should.BeNil)
+
+	assert := assertions.New(t)
+	assert.So(set.Insert("help"), should.BeTrue)
+	assert.So(set.Insert("Hello"), should.BeTrue)
+	assert.So(set.Insert("he"), should.BeTrue)
+	assert.So(set.Insert("world"), should.BeTrue)
+	assert.So(set.Insert("HELP"), should.BeFalse)
+	assert.So(set.Length(), should.Equal, 4)
+
+	testTable := map[string]struct {
+		Key      string
+		Expected bool
+	}{
+		"member":           {Key: "hello", Expected: true},
+		"transformed":      {Key: "WORLD", Expected: true},
+		"prefix-of-member": {Key: "hel", Expected: false},
+		"not-in-data":      {Key: "helper", Expected: false},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			assertions.New(t).So(set.Contains(testCase.Key), should.Equal, testCase.Expected)
+		})
+	}
+
+	assert.So(slices.Collect(set.All()), should.Equal, []string{"he", "hello", "help", "world"})
+	assert.So(slices.Collect(set.WithPrefix("HEL")), should.Equal, []string{"hello", "help"})
+	assert.So(slices.Collect(set.WithPrefix("x")), should.BeNil)
+}
+
+func Test_TrieSet_Remove(t *testing.T) {
+	set, _ := NewTrieSet[string]()
+	set.Insert("he")
+	set.Insert("hello")
+	set.Insert("help")
+
+	assert := assertions.New(t)
+	assert.So(set.Remove("hel"), should.BeFalse)
+	assert.So(set.Remove("hex"), should.BeFalse)
+	assert.So(set.Remove("hello"), should.BeTrue)
+	assert.So(set.Remove("hello"), should.BeFalse)
+	assert.So(set.Contains("hello"), should.BeFalse)
+	assert.So(set.Contains("help"), should.BeTrue)
+	assert.So(set.Length(), should.Equal, 2)
+
+	// The branch that only led to "hello" is pruned, and its nodes reused.
+	nodes := len(set.nodes)
+	hel, _ := set.descend([]uint8("hel"))
+	assert.So(set.nodes[set.nodes[hel].child].sibling, should.Equal, 0)
+	set.Insert("helm")
+	assert.So(len(set.nodes), should.Equal, nodes)
+	assert.So(slices.Collect(set.All()), should.Equal, []string{"he", "helm", "help"})
+
+	assert.So(set.Remove("he"), should.BeTrue)
+	assert.So(set.Contains("help"), should.BeTrue)
+	assert.So(set.Remove("help"), should.BeTrue)
+	assert.So(set.Remove("helm"), should.BeTrue)
+	assert.So(set.nodes[0].child, should.Equal, 0)
+	assert.So(set.Length(), should.Equal, 0)
+}
+
+func Test_TrieSet_BadKey(t *testing.T) {
+	set, err := NewTrieSet[[]int]()
+	assertions.New(t).So(set, should.BeNil)
+	assertions.New(t).So(err, should.Wrap, ErrorBadTrieKey)
+}
+
+func Test_TrieSet_NodeSize(t *testing.T) {
+	// The size of simpleNode[string, struct{}] before the options annotated it.
+	const baseline = 32
+	assertions.New(t).So(unsafe.Sizeof(setNode{}), should.BeLessThan, baseline)
+}
@@ -0,0 +1,194 @@
+package tries
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// Tuple is a composite key made up of several typed fields, such as a tenant
+// ID, a region and a timestamp, for a [Trie] created with [TupleCodec]. Each
+// field may be a string, a []byte, or any of the built-in integer types.
+// Tuples are ordered field by field, so a Tuple holding the leading fields of
+// other Tuples is a prefix of them for [SimpleTrie.WithPrefix] and
+// [SimpleTrie.CountPrefix].
+type Tuple []any
+
+// TupleCodec is a [KeyCodec] for [Tuple] keys. Each field is encoded as a type
+// tag followed by its value, which keeps keys unambiguous without a
+// separator: integers are fixed-width and big-endian with the sign bit of
+// signed integers flipped, so they are ordered numerically, and strings and
+// byte slices are terminated by 0x00 0x00 with any 0x00 within them escaped
+// as 0x00 0xFF, so they are ordered byte-wise and no field is mistaken for a
+// prefix of a longer one. Fields are decoded back to the types they were
+// added as. Transforms would corrupt the encoding, so a Trie of Tuples should
+// not be created with any. A Tuple with a field of any other type cannot be
+// encoded, and so is never stored or found.
+type TupleCodec struct{}
+
+const (
+	tupleBytes uint8 = iota + 1
+	tupleString
+	tupleUInt8
+	tupleUInt16
+	tupleUInt32
+	tupleUInt64
+	tupleUInt
+	tupleUIntptr
+	tupleInt8
+	tupleInt16
+	tupleInt32
+	tupleInt64
+	tupleInt
+)
+
+const (
+	tupleEscape     = 0x00
+	tupleEscaped    = 0xFF
+	tupleTerminator = 0x00
+)
+
+func (TupleCodec) Encode(key Tuple, path []uint8) (encoded []uint8, err error) {
+	for _, field := range key {
+		switch field := field.(type) {
+		case []uint8:
+			path = appendTupleBytes(append(path, tupleBytes), field)
+		case string:
+			path = appendTupleBytes(append(path, tupleString), []uint8(field))
+		case uint8:
+			path = append(path, tupleUInt8, field)
+		case uint16:
+			path = binary.BigEndian.AppendUint16(append(path, tupleUInt16), field)
+		case uint32:
+			path = binary.BigEndian.AppendUint32(append(path, tupleUInt32), field)
+		case uint64:
+			path = binary.BigEndian.AppendUint64(append(path, tupleUInt64), field)
+		case uint:
+			path = binary.BigEndian.AppendUint64(append(path, tupleUInt), uint64(field))
+		case uintptr:
+			path = binary.BigEndian.AppendUint64(append(path, tupleUIntptr), uint64(field))
+		case int8:
+			path = append(path, tupleInt8, uint8(field)^0x80) //nolint:gosec // this casting is fine
+		case int16:
+			path = binary.BigEndian.AppendUint16(append(path, tupleInt16), uint16(field)^0x8000) //nolint:gosec // this casting is fine
+		case int32:
+			path = binary.BigEndian.AppendUint32(append(path, tupleInt32), uint32(field)^0x80000000) //nolint:gosec // this casting is fine
+		case int64:
+			path = binary.BigEndian.AppendUint64(append(path, tupleInt64), uint64(field)^signBit64) //nolint:gosec // this casting is fine
+		case int:
+			path = binary.BigEndian.AppendUint64(append(path, tupleInt), uint64(field)^signBit64) //nolint:gosec // this casting is fine
+		default:
+			return path, fmt.Errorf("%w: unable to encode %T as a tuple field", ErrorBadTrieKey, field)
+		}
+	}
+
+	return path, nil
+}
+
+func (TupleCodec) Decode(path []uint8) (key Tuple, err error) {
+	for len(path) > 0 {
+		tag := path[0]
+		path = path[1:]
+
+		var field any
+		switch tag {
+		case tupleBytes, tupleString:
+			var value []uint8
+			if value, path, err = decodeTupleBytes(path); err != nil {
+				return nil, err
+			} else if tag == tupleString {
+				field = string(value)
+			} else {
+				field = value
+			}
+		default:
+			if field, path, err = decodeTupleInteger(tag, path); err != nil {
+				return nil, err
+			}
+		}
+
+		key = append(key, field)
+	}
+
+	return key, nil
+}
+
+func appendTupleBytes(path, value []uint8) []uint8 {
+	for _, unit := range value {
+		if unit == tupleEscape {
+			path = append(path, tupleEscape, tupleEscaped)
+		} else {
+			path = append(path, unit)
+		}
+	}
+
+	return append(path, tupleEscape, tupleTerminator)
+}
+
+func decodeTupleBytes(path []uint8) (value, remaining []uint8, err error) {
+	value = []uint8{}
+	for {
+		index := bytes.IndexByte(path, tupleEscape)
+		if index < 0 || index+1 >= len(path) {
+			return nil, nil, fmt.Errorf("%w: unterminated tuple field", ErrorBadTrieKey)
+		}
+
+		value = append(value, path[:index]...)
+		switch path[index+1] {
+		case tupleTerminator:
+			return value, path[index+2:], nil
+		case tupleEscaped:
+			value = append(value, tupleEscape)
+			path = path[index+2:]
+		default:
+			return nil, nil, fmt.Errorf("%w: invalid escape in tuple field", ErrorBadTrieKey)
+		}
+	}
+}
+
+func decodeTupleInteger(tag uint8, path []uint8) (field any, remaining []uint8, err error) {
+	width := 8
+	switch tag {
+	case tupleUInt8, tupleInt8:
+		width = 1
+	case tupleUInt16, tupleInt16:
+		width = 2
+	case tupleUInt32, tupleInt32:
+		width = 4
+	case tupleUInt64, tupleUInt, tupleUIntptr, tupleInt64, tupleInt:
+	default:
+		return nil, nil, fmt.Errorf("%w: unknown tuple field type %#x", ErrorBadTrieKey, tag)
+	}
+
+	if len(path) < width {
+		return nil, nil, fmt.Errorf("%w: expected %d bytes but found %d", ErrorBadTrieKey, width, len(path))
+	}
+
+	value, remaining := path[:width], path[width:]
+	switch tag {
+	case tupleUInt8:
+		field = value[0]
+	case tupleUInt16:
+		field = binary.BigEndian.Uint16(value)
+	case tupleUInt32:
+		field = binary.BigEndian.Uint32(value)
+	case tupleUInt64:
+		field = binary.BigEndian.Uint64(value)
+	case tupleUInt:
+		field = uint(binary.BigEndian.Uint64(value))
+	case tupleUIntptr:
+		field = uintptr(binary.BigEndian.Uint64(value))
+	case tupleInt8:
+		field = int8(value[0] ^ 0x80) //nolint:gosec // this casting is fine
+	case tupleInt16:
+		field = int16(binary.BigEndian.Uint16(value) ^ 0x8000) //nolint:gosec // this casting is fine
+	case tupleInt32:
+		field = int32(binary.BigEndian.Uint32(value) ^ 0x80000000) //nolint:gosec // this casting is fine
+	case tupleInt64:
+		field = int64(binary.BigEndian.Uint64(value) ^ signBit64) //nolint:gosec // this casting is fine
+	case tupleInt:
+		field = int(binary.BigEndian.Uint64(value) ^ signBit64) //nolint:gosec // this casting is fine
+	}
+
+	return field, remaining, nil
+}
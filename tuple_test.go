@@ -0,0 +1,121 @@
+package tries
+
+import (
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func Test_TupleCodec_RoundTrip(t *testing.T) {
+	testTable := map[string]struct {
+		Key Tuple
+	}{
+		"empty":    {Key: nil},
+		"strings":  {Key: Tuple{"", "a\x00b", "\x00\xFF"}},
+		"bytes":    {Key: Tuple{[]uint8{}, []uint8{0, 0, 1}}},
+		"unsigned": {Key: Tuple{uint8(1), uint16(2), uint32(3), uint64(4), uint(5), uintptr(6)}},
+		"signed":   {Key: Tuple{int8(-1), int16(-2), int32(-3), int64(-4), int(-5)}},
+		"mixed":    {Key: Tuple{uint32(7), "us-east", int64(1700000000)}},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			codec := TupleCodec{}
+			encoded, err := codec.Encode(testCase.Key, nil)
+			assertions.New(t).So(err, should.BeNil)
+			decoded, err := codec.Decode(encoded)
+			assertions.New(t).So(err, should.BeNil)
+			assertions.New(t).So(decoded, should.Equal, testCase.Key)
+		})
+	}
+}
+
+func Test_TupleCodec_Order(t *testing.T) {
+	trie, _ := NewTrieWithCodec[Tuple, int](TupleCodec{})
+	trie.Add(Tuple{int64(1), "b"}, 5)
+	trie.Add(Tuple{int64(1), "a\x00"}, 4)
+	trie.Add(Tuple{int64(1), "a"}, 3)
+	trie.Add(Tuple{int64(-1), "z"}, 2)
+	trie.Add(Tuple{int64(-2), "z"}, 1)
+
+	assertions.New(t).So(collectEntries(trie.All()), should.Equal, []Entry[Tuple, int]{
+		{Tuple{int64(-2), "z"}, 1},
+		{Tuple{int64(-1), "z"}, 2},
+		{Tuple{int64(1), "a"}, 3},
+		{Tuple{int64(1), "a\x00"}, 4},
+		{Tuple{int64(1), "b"}, 5},
+	})
+}
+
+func Test_TupleCodec_Prefix(t *testing.T) {
+	trie, _ := NewTrieWithCodec[Tuple, string](TupleCodec{}, WithSubtreeCounts())
+	trie.Add(Tuple{uint32(7), "us", int64(1)}, "a")
+	trie.Add(Tuple{uint32(7), "us", int64(2)}, "b")
+	trie.Add(Tuple{uint32(7), "usa", int64(1)}, "c")
+	trie.Add(Tuple{uint32(8), "us", int64(1)}, "d")
+
+	testTable := map[string]struct {
+		Prefix   Tuple
+		Expected []Entry[Tuple, string]
+	}{
+		"tenant": {Prefix: Tuple{uint32(7)}, Expected: []Entry[Tuple, string]{
+			{Tuple{uint32(7), "us", int64(1)}, "a"},
+			{Tuple{uint32(7), "us", int64(2)}, "b"},
+			{Tuple{uint32(7), "usa", int64(1)}, "c"},
+		}},
+		"tenant-region": {Prefix: Tuple{uint32(7), "us"}, Expected: []Entry[Tuple, string]{
+			{Tuple{uint32(7), "us", int64(1)}, "a"},
+			{Tuple{uint32(7), "us", int64(2)}, "b"},
+		}},
+		"not-in-data": {Prefix: Tuple{uint32(9)}, Expected: nil},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			assertions.New(t).So(collectEntries(trie.WithPrefix(testCase.Prefix)), should.Equal, testCase.Expected)
+			assertions.New(t).So(trie.CountPrefix(testCase.Prefix), should.Equal, len(testCase.Expected))
+		})
+	}
+}
+
+func Test_TupleCodec_DecodeErrors(t *testing.T) {
+	testTable := map[string]struct {
+		Path []uint8
+	}{
+		"unknown-tag":     {Path: []uint8{0xEE}},
+		"short-integer":   {Path: []uint8{tupleUInt32, 0, 0}},
+		"unterminated":    {Path: []uint8{tupleString, 'a', 'b'}},
+		"dangling-escape": {Path: []uint8{tupleString, 'a', tupleEscape}},
+		"bad-escape":      {Path: []uint8{tupleString, tupleEscape, 0x01}},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			_, err := TupleCodec{}.Decode(testCase.Path)
+			assertions.New(t).So(err, should.Wrap, ErrorBadTrieKey)
+		})
+	}
+}
+
+func Test_TupleCodec_UnsupportedField(t *testing.T) {
+	_, err := TupleCodec{}.Encode(Tuple{struct{}{}}, nil)
+	assertions.New(t).So(err, should.Wrap, ErrorBadTrieKey)
+
+	trie, _ := NewTrieWithCodec[Tuple, int](TupleCodec{})
+	trie.Add(Tuple{}, 1)
+	assert := assertions.New(t)
+	assert.So(trie.Add(Tuple{"a", struct{}{}}, 2), should.BeFalse)
+	assert.So(trie.Length(), should.Equal, 1)
+
+	value, found := trie.Find(Tuple{"a", struct{}{}})
+	assert.So(value, should.Equal, 0)
+	assert.So(found, should.BeFalse)
+
+	assert.So(collectEntries(trie.WithPrefix(Tuple{struct{}{}})), should.BeEmpty)
+	assert.So(trie.CountPrefix(Tuple{struct{}{}}), should.Equal, 0)
+	assert.So(trie.Cursor().Seek(Tuple{struct{}{}}), should.BeFalse)
+
+	_, _, found = trie.LongestPrefix(Tuple{struct{}{}})
+	assert.So(found, should.BeFalse)
+}
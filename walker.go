@@ -0,0 +1,74 @@
+package tries
+
+type simpleWalker[TKey any, TValue any] struct {
+	trie     *SimpleTrie[TKey, TValue]
+	node     *simpleNode[TKey, TValue] // node is nil once the walker has left the trie
+	pipeline *transformPipeline
+	buffer   []uint8
+	finished bool
+}
+
+func newSimpleWalker[TKey any, TValue any](trie *SimpleTrie[TKey, TValue]) *simpleWalker[TKey, TValue] {
+	walker := &simpleWalker[TKey, TValue]{
+		trie:     trie,
+		pipeline: newTransformPipeline(trie.stages),
+	}
+
+	walker.Reset()
+	return walker
+}
+
+func (this *simpleWalker[TKey, TValue]) Step(unit uint8) (ok bool) {
+	if this.node == nil || this.finished {
+		return false
+	}
+
+	// A unit may be ignored entirely (or held back) by the transforms, just as
+	// it is by Find, or may expand into several.
+	this.buffer = this.pipeline.push(unit, this.buffer[:0])
+	return this.advance()
+}
+
+func (this *simpleWalker[TKey, TValue]) Finish() (ok bool) {
+	if this.node == nil || this.finished {
+		return this.node != nil
+	}
+
+	this.finished = true
+	this.buffer = this.pipeline.flush(this.buffer[:0])
+	return this.advance()
+}
+
+// advance descends through the units in the buffer.
+func (this *simpleWalker[TKey, TValue]) advance() (ok bool) {
+	for _, unit := range this.buffer {
+		if this.node, ok = this.node.binarySearchNext(unit); !ok {
+			this.node = nil
+			return false
+		}
+	}
+
+	return true
+}
+
+func (this *simpleWalker[TKey, TValue]) HasValue() bool {
+	return this.node != nil && this.node.hasValue
+}
+
+func (this *simpleWalker[TKey, TValue]) Value() (value TValue) {
+	if !this.HasValue() {
+		return value
+	}
+
+	return this.node.value
+}
+
+func (this *simpleWalker[TKey, TValue]) CanContinue() bool {
+	return this.node != nil && len(this.node.next) > 0
+}
+
+func (this *simpleWalker[TKey, TValue]) Reset() {
+	this.node = &this.trie.head
+	this.finished = false
+	this.pipeline.reset()
+}
@@ -0,0 +1,126 @@
+package tries
+
+import (
+	"testing"
+
+	"github.com/smarty/assertions"
+	"github.com/smarty/assertions/should"
+)
+
+func Test_Walker_Step(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int]()
+	trie.Add("he", 1)
+	trie.Add("hello", 2)
+	trie.Add("help", 3)
+
+	type step struct {
+		OK          bool
+		HasValue    bool
+		Value       int
+		CanContinue bool
+	}
+
+	expected := []step{
+		{OK: true, HasValue: false, Value: 0, CanContinue: true}, // h
+		{OK: true, HasValue: true, Value: 1, CanContinue: true},  // he
+		{OK: true, HasValue: false, Value: 0, CanContinue: true}, // hel
+		{OK: true, HasValue: false, Value: 0, CanContinue: true}, // hell
+		{OK: true, HasValue: true, Value: 2, CanContinue: false}, // hello
+		{OK: false, HasValue: false, Value: 0, CanContinue: false},
+		{OK: false, HasValue: false, Value: 0, CanContinue: false},
+	}
+
+	and := assertions.New(t)
+	walker := trie.Walker()
+	for i, unit := range []byte("hellooo") {
+		ok := walker.Step(unit)
+		and.So(step{
+			OK:          ok,
+			HasValue:    walker.HasValue(),
+			Value:       walker.Value(),
+			CanContinue: walker.CanContinue(),
+		}, should.Equal, expected[i])
+	}
+
+	walker.Reset()
+	and.So(walker.Step('h'), should.BeTrue)
+	and.So(walker.CanContinue(), should.BeTrue)
+}
+
+func Test_Walker_StepWithTransform(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int](WithTransforms(func(in byte) (out byte, use bool) {
+		if in == '-' {
+			return 0, false
+		}
+
+		if in >= 'A' && in <= 'Z' {
+			return in - 'A' + 'a', true
+		}
+
+		return in, true
+	}))
+	trie.Add("Help", 1)
+
+	and := assertions.New(t)
+	walker := trie.Walker()
+	for _, unit := range []byte("H-E-L-P") {
+		and.So(walker.Step(unit), should.BeTrue)
+	}
+
+	and.So(walker.HasValue(), should.BeTrue)
+	and.So(walker.Value(), should.Equal, 1)
+}
+
+func Test_Walker_StepInterleavedWithFind(t *testing.T) {
+	trie, _ := NewTrieWithOptions[string, int](WithTransformers(ExpandAbbreviations(map[string]string{"st": "street"})))
+	trie.Add("st x", 1)
+	trie.Add("ab", 2)
+
+	and := assertions.New(t)
+	walker := trie.Walker()
+	and.So(walker.Step('s'), should.BeTrue)
+	and.So(walker.Step('t'), should.BeTrue)
+
+	value, found := trie.Find("ab")
+	and.So(value, should.Equal, 2)
+	and.So(found, should.BeTrue)
+
+	and.So(walker.Step(' '), should.BeTrue)
+	and.So(walker.CanContinue(), should.BeTrue)
+}
+
+func Test_Walker_Finish(t *testing.T) {
+	testTable := map[string]struct {
+		Options []Option
+		Stored  string
+		Input   string
+	}{
+		"abbreviations": {Options: []Option{WithTransformers(ExpandAbbreviations(map[string]string{"st": "street"}))}, Stored: "st x", Input: "st x"},
+		"normalized":    {Options: []Option{WithRuneTransforms(NFC)}, Stored: "éa", Input: "éa"},
+		"stateless":     {Options: []Option{WithTransforms(ASCIILower)}, Stored: "ab", Input: "AB"},
+	}
+
+	for name, testCase := range testTable {
+		t.Run(name, func(t *testing.T) {
+			trie, _ := NewTrieWithOptions[string, int](testCase.Options...)
+			trie.Add(testCase.Stored, 1)
+
+			and := assertions.New(t)
+			walker := trie.Walker()
+			for _, unit := range []byte(testCase.Input) {
+				and.So(walker.Step(unit), should.BeTrue)
+			}
+
+			and.So(walker.Finish(), should.BeTrue)
+			and.So(walker.HasValue(), should.BeTrue)
+			and.So(walker.Value(), should.Equal, 1)
+			and.So(walker.Finish(), should.BeTrue)
+			and.So(walker.Step('z'), should.BeFalse)
+			and.So(walker.HasValue(), should.BeTrue)
+
+			walker.Reset()
+			and.So(walker.HasValue(), should.BeFalse)
+			and.So(walker.Step(testCase.Input[0]), should.BeTrue)
+		})
+	}
+}